@@ -0,0 +1,409 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	cauth "github.com/pip-services3-gox/pip-services3-components-gox/auth"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisCredentialStore is a credential store that keeps credential parameters in Redis in-memory database.
+Credentials are encrypted at rest with AES-GCM using a configured encryption key.
+
+Every store of credentials adds a new version, so credentials can be rotated while
+the previous versions stay available for a while. To rotate the encryption key
+set the new key, move the old one to the previous keys and call Rotate to re-encrypt stored credentials.
+
+Configuration parameters:
+
+  - options:
+    - encryption_key:        a secret to derive the encryption key from
+    - previous_keys:         (optional) comma-separated secrets used before to decrypt not rotated credentials
+    - max_versions:          maximum number of kept versions of credentials (default: 5, 0 to keep all versions)
+    - prefix:                prefix of credential keys in Redis (default: "credentials")
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    store := NewRedisCredentialStore();
+    store.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.encryption_key", "my secret",
+    ));
+
+    err = store.Open(ctx, "123")
+      ...
+
+    err = store.Store(ctx, "123", "key1", cauth.NewCredentialParamsFromTuples(
+      "username", "user1",
+      "password", "pass1",
+    ))
+
+    credential, err := store.Lookup(ctx, "123", "key1")
+    fmt.Println(credential.Password())     // Result: "pass1"
+*/
+type RedisCredentialStore struct {
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	encryptionKey string
+	previousKeys  []string
+	maxVersions   int64
+	prefix        string
+
+	keyId string
+	aead  cipher.AEAD
+	aeads map[string]cipher.AEAD
+}
+
+// NewRedisCredentialStore method are creates a new instance of the credential store.
+func NewRedisCredentialStore() *RedisCredentialStore {
+	return &RedisCredentialStore{
+		connection:  rconnect.NewRedisConnection(),
+		logger:      *clog.NewCompositeLogger(),
+		maxVersions: 5,
+		prefix:      "credentials",
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisCredentialStore) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.encryptionKey = config.GetAsStringWithDefault("options.encryption_key", c.encryptionKey)
+	previousKeys := config.GetAsStringWithDefault("options.previous_keys", "")
+	if previousKeys != "" {
+		c.previousKeys = strings.Split(previousKeys, ",")
+	}
+	c.maxVersions = config.GetAsLongWithDefault("options.max_versions", c.maxVersions)
+	c.prefix = config.GetAsStringWithDefault("options.prefix", c.prefix)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisCredentialStore) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisCredentialStore) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisCredentialStore) Open(ctx context.Context, correlationId string) error {
+	if c.encryptionKey == "" {
+		return cerr.NewConfigError(correlationId, "NO_ENCRYPTION_KEY", "Encryption key is not configured")
+	}
+
+	c.aeads = make(map[string]cipher.AEAD)
+	for _, key := range append([]string{c.encryptionKey}, c.previousKeys...) {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		keyId, aead, err := c.newCipher(key)
+		if err != nil {
+			return cerr.NewConfigError(correlationId, "WRONG_ENCRYPTION_KEY", "Failed to create cipher").
+				WithCause(err)
+		}
+		if key == c.encryptionKey {
+			c.keyId = keyId
+			c.aead = aead
+		}
+		c.aeads[keyId] = aead
+	}
+
+	return c.connection.Open(ctx, correlationId)
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisCredentialStore) Close(ctx context.Context, correlationId string) error {
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisCredentialStore) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisCredentialStore) getKey(key string) string {
+	return c.prefix + ":" + key
+}
+
+// Store method are stores credential parameters as a new current version.
+// When credential parameters are nil all versions are removed.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the credential parameters.
+//   - credential        a credential parameters to be stored.
+// Returns: error or nil for success.
+func (c *RedisCredentialStore) Store(ctx context.Context, correlationId string, key string,
+	credential *cauth.CredentialParams) error {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	if credential == nil {
+		return c.connection.GetClient().Del(c.getKey(key)).Err()
+	}
+
+	data, err := json.Marshal(credential.Value())
+	if err != nil {
+		return err
+	}
+	value, err := c.encrypt(key, data)
+	if err != nil {
+		return err
+	}
+
+	version, err := storeScript.Run(c.connection.GetClient(), []string{c.getKey(key)}, value, c.maxVersions).Int64()
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug(ctx, correlationId, "Stored version %d of credentials %s", version, key)
+	return nil
+}
+
+// Lookup method are looks up the current version of credential parameters by their key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the credential parameters.
+// Returns: found credential parameters or error.
+func (c *RedisCredentialStore) Lookup(ctx context.Context, correlationId string,
+	key string) (*cauth.CredentialParams, error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	res, err := lookupScript.Run(c.connection.GetClient(), []string{c.getKey(key)}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values, ok := res.([]any)
+	if !ok || len(values) < 2 {
+		return nil, cerr.NewConfigError(correlationId, "MISSING_CREDENTIALS", "missing credential param: "+key)
+	}
+
+	return c.decrypt(correlationId, key, cconv.StringConverter.ToString(values[1]))
+}
+
+// LookupVersion method are looks up the specified version of credential parameters by their key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the credential parameters.
+//   - version           a number of the version.
+// Returns: found credential parameters or error.
+func (c *RedisCredentialStore) LookupVersion(ctx context.Context, correlationId string,
+	key string, version int64) (*cauth.CredentialParams, error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	value, err := c.connection.GetClient().HGet(c.getKey(key), "v"+strconv.FormatInt(version, 10)).Result()
+	if err == redis.Nil {
+		return nil, cerr.NewConfigError(correlationId, "MISSING_CREDENTIALS", "missing credential param: "+key).
+			WithDetails("version", version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decrypt(correlationId, key, value)
+}
+
+// GetVersions method are gets numbers of kept versions of credential parameters.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the credential parameters.
+// Returns: a sorted list of version numbers where the last one is the current version, or error.
+func (c *RedisCredentialStore) GetVersions(ctx context.Context, correlationId string, key string) ([]int64, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	fields, err := c.connection.GetClient().HKeys(c.getKey(key)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(fields))
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "v") {
+			continue
+		}
+		if version, err := strconv.ParseInt(field[1:], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
+}
+
+// Rotate method are re-encrypts all versions of credential parameters with the current encryption key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the credential parameters.
+// Returns: error or nil for success.
+func (c *RedisCredentialStore) Rotate(ctx context.Context, correlationId string, key string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	values, err := c.connection.GetClient().HGetAll(c.getKey(key)).Result()
+	if err != nil {
+		return err
+	}
+
+	args := make([]any, 0)
+	for field, value := range values {
+		if !strings.HasPrefix(field, "v") || strings.HasPrefix(value, c.keyId+":") {
+			continue
+		}
+
+		data, err := c.open(correlationId, key, value)
+		if err != nil {
+			return err
+		}
+		newValue, err := c.encrypt(key, data)
+		if err != nil {
+			return err
+		}
+		args = append(args, field, value, newValue)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	replaced, err := replaceScript.Run(c.connection.GetClient(), []string{c.getKey(key)}, args...).Int64()
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info(ctx, correlationId, "Re-encrypted %d versions of credentials %s", replaced, key)
+	return nil
+}
+
+func (c *RedisCredentialStore) newCipher(secret string) (string, cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+	keyHash := sha256.Sum256(key[:])
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", nil, err
+	}
+	return hex.EncodeToString(keyHash[:4]), aead, nil
+}
+
+// encrypt seals the data bound to the credential key.
+// The result has format "<key id>:<base64 of nonce and ciphertext>".
+func (c *RedisCredentialStore) encrypt(key string, data []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, data, []byte(key))
+	return c.keyId + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *RedisCredentialStore) open(correlationId string, key string, value string) ([]byte, error) {
+	keyId, encoded, ok := strings.Cut(value, ":")
+	aead := c.aeads[keyId]
+	if !ok || aead == nil {
+		return nil, cerr.NewConfigError(correlationId, "UNKNOWN_ENCRYPTION_KEY",
+			"Credentials "+key+" are encrypted with unknown key").WithDetails("key_id", keyId)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, cerr.NewInternalError(correlationId, "WRONG_CREDENTIALS", "Credentials "+key+" are corrupted")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, cerr.NewInternalError(correlationId, "WRONG_CREDENTIALS", "Failed to decrypt credentials "+key).
+			WithCause(err)
+	}
+	return data, nil
+}
+
+func (c *RedisCredentialStore) decrypt(correlationId string, key string, value string) (*cauth.CredentialParams, error) {
+	data, err := c.open(correlationId, key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]string
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, cerr.NewInternalError(correlationId, "WRONG_CREDENTIALS", "Credentials "+key+" are corrupted").
+			WithCause(err)
+	}
+	return cauth.NewCredentialParams(values), nil
+}
@@ -0,0 +1,54 @@
+package auth
+
+import "github.com/go-redis/redis"
+
+// Credentials are kept in a hash with encrypted versions in "v<N>" fields
+// and the number of the current version in the "version" field.
+
+// storeScript adds a new version of credentials and removes the versions above the limit.
+//   - KEYS[1]  a credentials key
+//   - ARGV[1]  encrypted credentials
+//   - ARGV[2]  a maximum number of kept versions (0 to keep all versions)
+// Returns: the number of the stored version.
+var storeScript = redis.NewScript(`
+local version = redis.call('HINCRBY', KEYS[1], 'version', 1)
+redis.call('HSET', KEYS[1], 'v' .. version, ARGV[1])
+local maxVersions = tonumber(ARGV[2])
+if maxVersions > 0 then
+	local old = version - maxVersions
+	while old > 0 and redis.call('HDEL', KEYS[1], 'v' .. old) == 1 do
+		old = old - 1
+	end
+end
+return version
+`)
+
+// lookupScript reads the current version of credentials.
+//   - KEYS[1]  a credentials key
+// Returns: an array with the version number and encrypted credentials or nil if nothing is stored.
+var lookupScript = redis.NewScript(`
+local version = redis.call('HGET', KEYS[1], 'version')
+if not version then
+	return false
+end
+local value = redis.call('HGET', KEYS[1], 'v' .. version)
+if not value then
+	return false
+end
+return {tonumber(version), value}
+`)
+
+// replaceScript replaces encrypted versions of credentials unless they were changed concurrently.
+//   - KEYS[1]  a credentials key
+//   - ARGV     triples of a version field, an expected value and a new value
+// Returns: a number of replaced versions.
+var replaceScript = redis.NewScript(`
+local replaced = 0
+for i = 1, #ARGV, 3 do
+	if redis.call('HGET', KEYS[1], ARGV[i]) == ARGV[i + 1] then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 2])
+		replaced = replaced + 1
+	end
+end
+return replaced
+`)
@@ -3,21 +3,63 @@ package build
 import (
 	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
 	cbuild "github.com/pip-services3-gox/pip-services3-components-gox/build"
+	redisauth "github.com/pip-services3-gox/pip-services3-redis-gox/auth"
 	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	redisconfig "github.com/pip-services3-gox/pip-services3-redis-gox/config"
+	redisconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	rediscount "github.com/pip-services3-gox/pip-services3-redis-gox/count"
 	redislock "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
+	redislog "github.com/pip-services3-gox/pip-services3-redis-gox/log"
+	redisqueues "github.com/pip-services3-gox/pip-services3-redis-gox/queues"
+	redisratelimit "github.com/pip-services3-gox/pip-services3-redis-gox/ratelimit"
+	redisscripts "github.com/pip-services3-gox/pip-services3-redis-gox/scripts"
+	redisstate "github.com/pip-services3-gox/pip-services3-redis-gox/state"
 )
 
 /*
 DefaultRedisFactory are creates Redis components by their descriptors.
 
 See RedisCache
+See RedisMap
+See RedisList
+See RedisSet
+See RedisScripts
 See RedisLock
+See RedisReadWriteLock
+See RedisSemaphore
+See RedisLeaderElector
+See RedisRateLimiter
+See RedisStateStore
+See RedisMessageQueue
+See RedisPubSub
+See RedisDiscovery
+See RedisCredentialStore
+See RedisCounters
+See RedisLogger
+See RedisConfigReader
 */
 type DefaultRedisFactory struct {
 	*cbuild.Factory
 	Descriptor           *cref.Descriptor
 	RedisCacheDescriptor *cref.Descriptor
 	RedisLockDescriptor  *cref.Descriptor
+
+	RedisReadWriteLockDescriptor   *cref.Descriptor
+	RedisSemaphoreDescriptor       *cref.Descriptor
+	RedisLeaderElectorDescriptor   *cref.Descriptor
+	RedisRateLimiterDescriptor     *cref.Descriptor
+	RedisStateStoreDescriptor      *cref.Descriptor
+	RedisMessageQueueDescriptor    *cref.Descriptor
+	RedisPubSubDescriptor          *cref.Descriptor
+	RedisDiscoveryDescriptor       *cref.Descriptor
+	RedisCredentialStoreDescriptor *cref.Descriptor
+	RedisCountersDescriptor        *cref.Descriptor
+	RedisLoggerDescriptor          *cref.Descriptor
+	RedisConfigReaderDescriptor    *cref.Descriptor
+	RedisMapDescriptor             *cref.Descriptor
+	RedisListDescriptor            *cref.Descriptor
+	RedisSetDescriptor             *cref.Descriptor
+	RedisScriptsDescriptor         *cref.Descriptor
 }
 
 // NewDefaultRedisFactory method are create a new instance of the factory.
@@ -28,7 +70,55 @@ func NewDefaultRedisFactory() *DefaultRedisFactory {
 	c.Descriptor = cref.NewDescriptor("pip-services", "factory", "redis", "default", "1.0")
 	c.RedisCacheDescriptor = cref.NewDescriptor("pip-services", "cache", "redis", "*", "1.0")
 	c.RedisLockDescriptor = cref.NewDescriptor("pip-services", "lock", "redis", "*", "1.0")
+	c.RedisReadWriteLockDescriptor = cref.NewDescriptor("pip-services", "read-write-lock", "redis", "*", "1.0")
+	c.RedisSemaphoreDescriptor = cref.NewDescriptor("pip-services", "semaphore", "redis", "*", "1.0")
+	c.RedisLeaderElectorDescriptor = cref.NewDescriptor("pip-services", "leader-elector", "redis", "*", "1.0")
+	c.RedisRateLimiterDescriptor = cref.NewDescriptor("pip-services", "rate-limiter", "redis", "*", "1.0")
+	c.RedisStateStoreDescriptor = cref.NewDescriptor("pip-services", "state-store", "redis", "*", "1.0")
+	c.RedisMessageQueueDescriptor = cref.NewDescriptor("pip-services", "message-queue", "redis", "*", "1.0")
+	c.RedisPubSubDescriptor = cref.NewDescriptor("pip-services", "pubsub", "redis", "*", "1.0")
+	c.RedisDiscoveryDescriptor = cref.NewDescriptor("pip-services", "discovery", "redis", "*", "1.0")
+	c.RedisCredentialStoreDescriptor = cref.NewDescriptor("pip-services", "credential-store", "redis", "*", "1.0")
+	c.RedisCountersDescriptor = cref.NewDescriptor("pip-services", "counters", "redis", "*", "1.0")
+	c.RedisLoggerDescriptor = cref.NewDescriptor("pip-services", "logger", "redis", "*", "1.0")
+	c.RedisConfigReaderDescriptor = cref.NewDescriptor("pip-services", "config-reader", "redis", "*", "1.0")
+	c.RedisMapDescriptor = cref.NewDescriptor("pip-services", "map", "redis", "*", "1.0")
+	c.RedisListDescriptor = cref.NewDescriptor("pip-services", "list", "redis", "*", "1.0")
+	c.RedisSetDescriptor = cref.NewDescriptor("pip-services", "set", "redis", "*", "1.0")
+	c.RedisScriptsDescriptor = cref.NewDescriptor("pip-services", "scripts", "redis", "*", "1.0")
 	c.RegisterType(c.RedisCacheDescriptor, rediscache.NewRedisCache[any])
 	c.RegisterType(c.RedisLockDescriptor, redislock.NewRedisLock)
+	c.RegisterType(c.RedisReadWriteLockDescriptor, redislock.NewRedisReadWriteLock)
+	c.RegisterType(c.RedisSemaphoreDescriptor, redislock.NewRedisSemaphore)
+	c.RegisterType(c.RedisLeaderElectorDescriptor, redislock.NewRedisLeaderElector)
+	c.RegisterType(c.RedisRateLimiterDescriptor, redisratelimit.NewRedisRateLimiter)
+	c.RegisterType(c.RedisStateStoreDescriptor, redisstate.NewRedisStateStore[any])
+	c.RegisterType(c.RedisPubSubDescriptor, redisqueues.NewRedisPubSub)
+	c.RegisterType(c.RedisDiscoveryDescriptor, redisconnect.NewRedisDiscovery)
+	c.RegisterType(c.RedisCredentialStoreDescriptor, redisauth.NewRedisCredentialStore)
+	c.RegisterType(c.RedisCountersDescriptor, rediscount.NewRedisCounters)
+	c.RegisterType(c.RedisLoggerDescriptor, redislog.NewRedisLogger)
+	c.RegisterType(c.RedisConfigReaderDescriptor, redisconfig.NewRedisConfigReader)
+	c.RegisterType(c.RedisScriptsDescriptor, redisscripts.NewRedisScripts)
+	c.Register(c.RedisMessageQueueDescriptor, func(locator any) any {
+		return redisqueues.NewRedisMessageQueue(getDescriptorName(locator))
+	})
+	c.Register(c.RedisMapDescriptor, func(locator any) any {
+		return rediscache.NewRedisMap[string, any](getDescriptorName(locator))
+	})
+	c.Register(c.RedisListDescriptor, func(locator any) any {
+		return rediscache.NewRedisList[any](getDescriptorName(locator))
+	})
+	c.Register(c.RedisSetDescriptor, func(locator any) any {
+		return rediscache.NewRedisSet[any](getDescriptorName(locator))
+	})
 	return &c
 }
+
+// getDescriptorName gets a name of the component from its locator.
+func getDescriptorName(locator any) string {
+	if descriptor, ok := locator.(*cref.Descriptor); ok {
+		return descriptor.Name()
+	}
+	return ""
+}
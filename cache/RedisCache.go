@@ -2,8 +2,6 @@ package persistence
 
 import (
 	"context"
-
-	"strconv"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -11,14 +9,21 @@ import (
 	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
-	cauth "github.com/pip-services3-gox/pip-services3-components-gox/auth"
-	ccon "github.com/pip-services3-gox/pip-services3-components-gox/connect"
 	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	rscripts "github.com/pip-services3-gox/pip-services3-redis-gox/scripts"
 )
 
 /*
 Distributed cache that stores values in Redis in-memory database.
 
+By default values are stored as JSON strings. In hash mode struct values are stored as Redis hashes
+with a field per struct field named by "redis" or "json" tags. Such values can be partially read
+and updated with RetrieveFields and UpdateFields.
+
+Lua scripts registered in the registry returned by GetScripts run on the cache connection
+and are preloaded when the cache is opened.
+
 Configuration parameters:
 
   - connection(s):
@@ -35,6 +40,7 @@ Configuration parameters:
     - timeout:               default caching timeout in milliseconds (default: 1 minute)
     - db_num:                database number in Redis  (default 0)
     - max_size:            	 maximum number of values stored in this cache (default: 1000)
+    - hash_mode:             stores struct values as hashes (default: false)
     - cluster:            	 enable redis cluster
 
 References:
@@ -64,56 +70,50 @@ Example:
     fmt.Println(string(value))     // Result: "ABC"
 */
 type RedisCache[T any] struct {
-	connectionResolver *ccon.ConnectionResolver
-	credentialResolver *cauth.CredentialResolver
+	defaultConfig *cconf.ConfigParams
 
-	timeout   int
-	retries   int
-	dbNum     int
-	isCluster bool
+	connection *rconnect.RedisConnection
+	hashMode   bool
 
-	client redis.UniversalClient
-	logger clog.CompositeLogger
+	client  redis.UniversalClient
+	logger  clog.CompositeLogger
+	scripts *rscripts.RedisScripts
 
 	convertor cconv.IJSONEngine[T]
 }
 
 // NewRedisCache method are creates a new instance of this cache.
 func NewRedisCache[T any]() *RedisCache[T] {
-	return &RedisCache[T]{
-		logger:             *clog.NewCompositeLogger(),
-		connectionResolver: ccon.NewEmptyConnectionResolver(),
-		credentialResolver: cauth.NewEmptyCredentialResolver(),
-		timeout:            30000,
-		retries:            30000,
-		dbNum:              3,
-		convertor:          cconv.NewDefaultCustomTypeJsonConvertor[T](),
+	c := &RedisCache[T]{
+		defaultConfig: cconf.NewConfigParamsFromTuples(
+			"options.retries", 30000,
+			"options.db_num", 3,
+		),
+		connection: rconnect.NewRedisConnection(),
+		logger:     *clog.NewCompositeLogger(),
+		convertor:  cconv.NewDefaultCustomTypeJsonConvertor[T](),
+		scripts:    rscripts.NewRedisScripts(),
 	}
+	c.scripts.Register(updateFieldsScriptName, updateFieldsScript)
+	return c
 }
 
 // Configure method are configures component by passing configuration parameters.
 // 	 - ctx context.Context
 //   - config    configuration parameters to be set.
 func (c *RedisCache[T]) Configure(ctx context.Context, config *cconf.ConfigParams) {
-	c.connectionResolver.Configure(ctx, config)
-	c.credentialResolver.Configure(ctx, config)
+	config = config.SetDefaults(c.defaultConfig)
+	c.connection.Configure(ctx, config)
 	c.logger.Configure(ctx, config)
 
-	c.timeout = config.GetAsIntegerWithDefault("options.timeout", c.timeout)
-	c.retries = config.GetAsIntegerWithDefault("options.retries", c.retries)
-	c.dbNum = config.GetAsIntegerWithDefault("options.db_num", c.dbNum)
-	if c.dbNum > 15 || c.dbNum < 0 {
-		c.dbNum = 0
-	}
-	c.isCluster = config.GetAsBooleanWithDefault("options.cluster", c.isCluster)
+	c.hashMode = config.GetAsBooleanWithDefault("options.hash_mode", c.hashMode)
 }
 
 // Sets references to dependent components.
 //	 - ctx context.Context
 //   - references 	references to locate the component dependencies.
 func (c *RedisCache[T]) SetReferences(ctx context.Context, references cref.IReferences) {
-	c.connectionResolver.SetReferences(ctx, references)
-	c.credentialResolver.SetReferences(ctx, references)
+	c.connection.SetReferences(ctx, references)
 	c.logger.SetReferences(ctx, references)
 }
 
@@ -129,60 +129,19 @@ func (c *RedisCache[T]) IsOpen() bool {
 //  - correlationId 	(optional) transaction id to trace execution through call chain.
 // Returns: error or nil no errors occured.
 func (c *RedisCache[T]) Open(ctx context.Context, correlationId string) error {
-	var (
-		connection *ccon.ConnectionParams
-		credential *cauth.CredentialParams
-		options    redis.Options
-	)
-
-	connection, err := c.connectionResolver.Resolve(correlationId)
-
-	if err == nil && connection == nil {
-		err = cerr.NewConfigError(correlationId, "NO_CONNECTION", "Connection is not configured")
-		return err
+	if c.hashMode {
+		if err := c.checkHashMode(correlationId); err != nil {
+			return err
+		}
 	}
 
-	credential, err = c.credentialResolver.Lookup(ctx, correlationId)
-
-	if err != nil {
+	if err := c.connection.Open(ctx, correlationId); err != nil {
 		return err
 	}
+	c.client = c.connection.GetClient()
 
-	options.DialTimeout = time.Duration(c.timeout) * time.Millisecond
-	options.DB = c.dbNum
-	options.MaxRetries = c.retries
-
-	if credential != nil {
-		options.Password = credential.Password()
-	}
-
-	if connection.Uri() != "" {
-		options.Addr = connection.Uri()
-	} else {
-		host := connection.Host()
-		if host == "" {
-			host = "localhost"
-		}
-		port := strconv.FormatInt(int64(connection.Port()), 10)
-		if port == "0" {
-			port = "6379"
-		}
-		options.Addr = host + ":" + port
-	}
-	if c.isCluster {
-		c.client = redis.NewClusterClient(&redis.ClusterOptions{
-			OnNewNode: func(client *redis.Client) {
-				client = redis.NewClient(&options)
-			},
-			Addrs:       append([]string{}, options.Addr),
-			Password:    options.Password,
-			DialTimeout: options.DialTimeout,
-		})
-	} else {
-		c.client = redis.NewClient(&options)
-	}
-	_, err = c.client.Ping().Result()
-	return err
+	c.scripts.SetExecutor(rscripts.NewClientScriptExecutor(c.client))
+	return c.scripts.Open(ctx, correlationId)
 }
 
 // Close method are closes component and frees used resources.
@@ -191,14 +150,14 @@ func (c *RedisCache[T]) Open(ctx context.Context, correlationId string) error {
 //   - correlationId 	(optional) transaction id to trace execution through call chain.
 // Retruns: error or nil no errors occured.
 func (c *RedisCache[T]) Close(ctx context.Context, correlationId string) error {
-	if c.client != nil {
-		err := c.client.Close()
-		c.client = nil
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	c.scripts.Close(ctx, correlationId)
+	c.client = nil
+	return c.connection.Close(ctx, correlationId)
+}
+
+// GetScripts method are gets a registry of Lua scripts executed on the cache connection.
+func (c *RedisCache[T]) GetScripts() *rscripts.RedisScripts {
+	return c.scripts
 }
 
 func (c *RedisCache[T]) checkOpened(correlationId string) (state bool, err error) {
@@ -224,6 +183,10 @@ func (c *RedisCache[T]) Retrieve(ctx context.Context, correlationId string, key
 		return defaultValue, err
 	}
 
+	if c.hashMode {
+		return c.retrieveHash(key)
+	}
+
 	item, err := c.client.Get(key).Bytes()
 
 	if err != nil {
@@ -261,6 +224,10 @@ func (c *RedisCache[T]) Store(ctx context.Context, correlationId string, key str
 		return defaultValue, err
 	}
 
+	if c.hashMode {
+		return value, c.storeHash(key, value, timeout)
+	}
+
 	jsonVal, err := c.convertor.ToJson(value)
 	if err != nil {
 		return defaultValue, err
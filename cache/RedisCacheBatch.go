@@ -0,0 +1,271 @@
+package persistence
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// A number of hash slots in Redis cluster.
+const clusterSlots = 16384
+
+// RedisCacheResult is a typed result of an operation queued in RedisCacheBatch.
+// It is set after the batch is executed.
+type RedisCacheResult[R any] struct {
+	value R
+	err   error
+}
+
+// Value method are gets the result value. It is the zero value when the operation failed.
+func (r *RedisCacheResult[R]) Value() R {
+	return r.value
+}
+
+// Err method are gets the error of the operation or nil for success.
+func (r *RedisCacheResult[R]) Err() error {
+	return r.err
+}
+
+// Result method are gets the result value and the error of the operation.
+func (r *RedisCacheResult[R]) Result() (R, error) {
+	return r.value, r.err
+}
+
+type batchOperation struct {
+	key string
+	// queue adds commands of the operation into the pipeline and returns a function
+	// that sets the result after the pipeline is executed.
+	queue func(pipe redis.Pipeliner) func()
+}
+
+/*
+RedisCacheBatch queues Store, Remove and Increment operations over several keys of RedisCache
+and executes them at once. Transactions created by RedisCache.Transaction are executed atomically with MULTI/EXEC,
+batches created by RedisCache.Batch are pipelined without atomicity.
+Operations of a transaction are executed without interleaving with other clients,
+but Redis does not roll them back when one of them fails at runtime.
+
+In Redis cluster all keys of a transaction must belong to the same hash slot, e.g. share the same
+hash tag in curly braces. Transactions with keys in different slots are rejected before execution.
+
+Example:
+	tx := cache.Transaction()
+	order := tx.Store("{order:1}:data", order, 60000)
+	count := tx.Increment("{order:1}:views", 1)
+	tx.Remove("{order:1}:draft")
+
+	err := tx.Execute(ctx, "123")
+	fmt.Println(count.Value())     // Result: 1
+*/
+type RedisCacheBatch[T any] struct {
+	cache      *RedisCache[T]
+	atomic     bool
+	operations []batchOperation
+	err        error
+}
+
+// Transaction method are creates a builder of operations executed atomically with MULTI/EXEC.
+func (c *RedisCache[T]) Transaction() *RedisCacheBatch[T] {
+	return &RedisCacheBatch[T]{
+		cache:      c,
+		atomic:     true,
+		operations: make([]batchOperation, 0),
+	}
+}
+
+// Batch method are creates a builder of operations pipelined without atomicity.
+func (c *RedisCache[T]) Batch() *RedisCacheBatch[T] {
+	return &RedisCacheBatch[T]{
+		cache:      c,
+		atomic:     false,
+		operations: make([]batchOperation, 0),
+	}
+}
+
+// Store method are queues storing of a value with expiration time as RedisCache.Store does.
+// Parameters:
+//   - key               a unique value key.
+//   - value             a value to store.
+//   - timeout           expiration timeout in milliseconds.
+// Returns: a result with the stored value.
+func (b *RedisCacheBatch[T]) Store(key string, value T, timeout int64) *RedisCacheResult[T] {
+	result := &RedisCacheResult[T]{}
+
+	var fields map[string]any
+	var data string
+	var err error
+	if b.cache.hashMode {
+		fields, err = b.cache.toHash(value)
+	} else {
+		data, err = b.cache.convertor.ToJson(value)
+	}
+	if err != nil {
+		result.err = err
+		if b.err == nil {
+			b.err = err
+		}
+		return result
+	}
+
+	b.operations = append(b.operations, batchOperation{
+		key: key,
+		queue: func(pipe redis.Pipeliner) func() {
+			var cmd redis.Cmder
+			if b.cache.hashMode {
+				cmd = queueStoreHash(pipe, key, fields, timeout)
+			} else {
+				cmd = pipe.Set(key, data, time.Duration(timeout)*time.Millisecond)
+			}
+			return func() {
+				if result.err = cmd.Err(); result.err == nil {
+					result.value = value
+				}
+			}
+		},
+	})
+	return result
+}
+
+// Remove method are queues removing of a value by its key.
+// Parameters:
+//   - key               a unique value key.
+// Returns: a result with true if the value existed.
+func (b *RedisCacheBatch[T]) Remove(key string) *RedisCacheResult[bool] {
+	result := &RedisCacheResult[bool]{}
+
+	b.operations = append(b.operations, batchOperation{
+		key: key,
+		queue: func(pipe redis.Pipeliner) func() {
+			cmd := pipe.Del(key)
+			return func() {
+				var count int64
+				count, result.err = cmd.Result()
+				result.value = count > 0
+			}
+		},
+	})
+	return result
+}
+
+// Increment method are queues incrementing of an integer value by its key.
+// A missing value is considered to be 0. The expiration of the value is not changed.
+// Values stored in hash mode cannot be incremented.
+// Parameters:
+//   - key               a unique value key.
+//   - delta             a value to add.
+// Returns: a result with the value after the increment.
+func (b *RedisCacheBatch[T]) Increment(key string, delta int64) *RedisCacheResult[int64] {
+	result := &RedisCacheResult[int64]{}
+
+	b.operations = append(b.operations, batchOperation{
+		key: key,
+		queue: func(pipe redis.Pipeliner) func() {
+			cmd := pipe.IncrBy(key, delta)
+			return func() {
+				result.value, result.err = cmd.Result()
+			}
+		},
+	})
+	return result
+}
+
+// Len method are gets a number of queued operations.
+func (b *RedisCacheBatch[T]) Len() int {
+	return len(b.operations)
+}
+
+// Execute method are executes the queued operations and sets their results.
+// Operations are removed from the builder, so it can be reused for the next set of operations.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: error of the first failed operation or nil for success. Transactions are not rolled back,
+// so when an operation fails at runtime, e.g. Increment of a non-integer value, the other operations
+// are still applied. Results of single operations shall be checked on error.
+func (b *RedisCacheBatch[T]) Execute(ctx context.Context, correlationId string) error {
+	operations, err := b.operations, b.err
+	b.operations = make([]batchOperation, 0)
+	b.err = nil
+
+	if err != nil {
+		return err
+	}
+	if b.atomic && b.cache.connection.IsCluster() && len(operations) > 0 {
+		if err = checkKeySlots(correlationId, operations); err != nil {
+			return err
+		}
+	}
+	if state, err := b.cache.checkOpened(correlationId); !state {
+		return err
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	completes := make([]func(), len(operations))
+	fn := func(pipe redis.Pipeliner) error {
+		for i, operation := range operations {
+			completes[i] = operation.queue(pipe)
+		}
+		return nil
+	}
+
+	if b.atomic {
+		_, err = b.cache.client.TxPipelined(fn)
+	} else {
+		_, err = b.cache.client.Pipelined(fn)
+	}
+
+	for _, complete := range completes {
+		if complete != nil {
+			complete()
+		}
+	}
+	return err
+}
+
+// checkKeySlots checks that all keys of the operations belong to the same cluster hash slot.
+func checkKeySlots(correlationId string, operations []batchOperation) error {
+	first := operations[0].key
+	slot := getKeySlot(first)
+	for _, operation := range operations[1:] {
+		if getKeySlot(operation.key) != slot {
+			return cerr.NewBadRequestError(correlationId, "CROSS_SLOT",
+				"Keys "+first+" and "+operation.key+" of the transaction belong to different cluster hash slots").
+				WithDetails("slot1", strconv.Itoa(slot)).
+				WithDetails("slot2", strconv.Itoa(getKeySlot(operation.key)))
+		}
+	}
+	return nil
+}
+
+// getKeySlot calculates a cluster hash slot of the key. When the key has a non-empty hash tag
+// in curly braces, only the tag is hashed.
+func getKeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16([]byte(key)) % clusterSlots)
+}
+
+// crc16 calculates CRC16 checksum with XMODEM parameters used by Redis cluster.
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
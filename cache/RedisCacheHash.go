@@ -0,0 +1,375 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// A name of the script registered in RedisCache scripts.
+const updateFieldsScriptName = "cache:update_fields"
+
+// updateFieldsScript updates fields of the hash only when the hash exists, so the expiration is kept
+// and an expired value is not recreated partially.
+const updateFieldsScript = `
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV, 2 do
+	redis.call("HSET", KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1
+`
+
+// hashField describes a struct field stored as a field of Redis hash.
+type hashField struct {
+	name  string
+	index []int
+}
+
+// getHashFields gets fields of the struct type with names taken from "redis" or "json" tags.
+// Fields tagged with "-" and unexported fields are skipped, fields of embedded structs are flattened.
+func getHashFields(t reflect.Type) []hashField {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]hashField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, ok := field.Tag.Lookup("redis")
+		if !ok {
+			tag = field.Tag.Get("json")
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for _, embedded := range getHashFields(field.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, hashField{name: name, index: field.Index})
+	}
+	return fields
+}
+
+// checkHashMode checks that values can be stored as hashes.
+func (c *RedisCache[T]) checkHashMode(correlationId string) error {
+	var value T
+	if getHashFields(reflect.TypeOf(&value).Elem()) == nil {
+		return cerr.NewConfigError(correlationId, "WRONG_MODE", "Hash mode requires values to be structs").
+			WithDetails("type", reflect.TypeOf(&value).Elem().String())
+	}
+	return nil
+}
+
+// checkFieldsAccess checks that values are stored in hash mode to access their fields.
+func (c *RedisCache[T]) checkFieldsAccess(correlationId string) error {
+	if !c.hashMode {
+		return cerr.NewInvalidStateError(correlationId, "NOT_HASH_MODE", "Fields are accessible only in hash mode")
+	}
+	return nil
+}
+
+// getStructValue gets the struct value of the item allocating nil pointers when requested.
+func getStructValue(value reflect.Value, allocate bool) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if !allocate {
+				return reflect.Value{}, false
+			}
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	return value, value.Kind() == reflect.Struct
+}
+
+// toHash converts the value into fields of Redis hash. Nil fields are not stored.
+func (c *RedisCache[T]) toHash(value T) (map[string]any, error) {
+	structValue, ok := getStructValue(reflect.ValueOf(&value).Elem(), false)
+	if !ok {
+		return map[string]any{}, nil
+	}
+
+	result := make(map[string]any)
+	for _, field := range getHashFields(structValue.Type()) {
+		data, ok, err := encodeHashValue(structValue.FieldByIndex(field.index))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[field.name] = data
+		}
+	}
+	return result, nil
+}
+
+// fromHash converts fields of Redis hash into a value. Unknown fields are ignored.
+func (c *RedisCache[T]) fromHash(values map[string]string) (T, error) {
+	var result T
+
+	structValue, _ := getStructValue(reflect.ValueOf(&result).Elem(), true)
+	for _, field := range getHashFields(structValue.Type()) {
+		data, ok := values[field.name]
+		if !ok {
+			continue
+		}
+		if err := decodeHashValue(data, structValue.FieldByIndex(field.index)); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// encodeHashValue converts a value of simple type into a string and other values into JSON.
+// It returns false for nil values.
+func encodeHashValue(value reflect.Value) (string, bool, error) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if value.IsNil() {
+			return "", false, nil
+		}
+	}
+	if value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		return encodeHashValue(value.Elem())
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), true, nil
+	}
+
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// decodeHashValue sets a value converted from the string stored by encodeHashValue.
+func decodeHashValue(data string, value reflect.Value) error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return decodeHashValue(data, value.Elem())
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(data)
+	case reflect.Bool:
+		result, err := strconv.ParseBool(data)
+		if err != nil {
+			return err
+		}
+		value.SetBool(result)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err := strconv.ParseInt(data, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(result)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result, err := strconv.ParseUint(data, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(result)
+	case reflect.Float32, reflect.Float64:
+		result, err := strconv.ParseFloat(data, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(result)
+	default:
+		return json.Unmarshal([]byte(data), value.Addr().Interface())
+	}
+	return nil
+}
+
+// setHashValue sets the field to the value converted into the field type.
+// Values of other types are converted through JSON, so numbers are checked for overflow
+// and values of incompatible types are rejected.
+func setHashValue(value any, field reflect.Value) error {
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(field.Type()) {
+		field.Set(source)
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, field.Addr().Interface())
+}
+
+func (c *RedisCache[T]) retrieveHash(key string) (T, error) {
+	var defaultValue T
+
+	values, err := c.client.HGetAll(key).Result()
+	if err != nil {
+		return defaultValue, err
+	}
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+	return c.fromHash(values)
+}
+
+func (c *RedisCache[T]) storeHash(key string, value T, timeout int64) error {
+	fields, err := c.toHash(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		queueStoreHash(pipe, key, fields, timeout)
+		return nil
+	})
+	return err
+}
+
+// queueStoreHash adds commands to replace the hash with the expiration as SET does into the pipeline.
+// It returns the command that writes the fields.
+func queueStoreHash(pipe redis.Pipeliner, key string, fields map[string]any, timeout int64) redis.Cmder {
+	var cmd redis.Cmder = pipe.Del(key)
+	if len(fields) > 0 {
+		cmd = pipe.HMSet(key, fields)
+	}
+	if timeout > 0 {
+		pipe.PExpire(key, time.Duration(timeout)*time.Millisecond)
+	}
+	return cmd
+}
+
+// RetrieveFields method are retrieves only selected fields of a value stored in hash mode.
+// Fields are named by "redis" or "json" tags of the value struct.
+// If value is missing in the cache or expired it returns the zero value.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique value key.
+//   - fields            names of fields to retrieve.
+// Returns: a value with only the retrieved fields set or error.
+func (c *RedisCache[T]) RetrieveFields(ctx context.Context, correlationId string, key string, fields ...string) (value T, err error) {
+	var defaultValue T
+
+	if state, err := c.checkOpened(correlationId); !state {
+		return defaultValue, err
+	}
+	if err = c.checkFieldsAccess(correlationId); err != nil || len(fields) == 0 {
+		return defaultValue, err
+	}
+
+	items, err := c.client.HMGet(key, fields...).Result()
+	if err != nil {
+		return defaultValue, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for i, item := range items {
+		if data, ok := item.(string); ok {
+			values[fields[i]] = data
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+	return c.fromHash(values)
+}
+
+// UpdateFields method are updates only selected fields of a value stored in hash mode.
+// Fields are named by "redis" or "json" tags of the value struct. The expiration of the value is not changed.
+// Values are converted into types of the fields and BadRequestError is returned when they cannot be converted.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique value key.
+//   - values            new values of the fields.
+// Returns: true if the value was updated, false if it is missing in the cache or expired, or error.
+func (c *RedisCache[T]) UpdateFields(ctx context.Context, correlationId string, key string, values map[string]any) (bool, error) {
+	if state, err := c.checkOpened(correlationId); !state {
+		return false, err
+	}
+	if err := c.checkFieldsAccess(correlationId); err != nil {
+		return false, err
+	}
+	if len(values) == 0 {
+		return c.client.Exists(key).Val() > 0, nil
+	}
+
+	var item T
+	structValue, _ := getStructValue(reflect.ValueOf(&item).Elem(), true)
+	known := make(map[string][]int)
+	for _, field := range getHashFields(structValue.Type()) {
+		known[field.name] = field.index
+	}
+
+	args := make([]any, 0, len(values)*2)
+	for name, value := range values {
+		index, ok := known[name]
+		if !ok {
+			return false, cerr.NewBadRequestError(correlationId, "UNKNOWN_FIELD", "Field "+name+" is not defined in the value").
+				WithDetails("field", name)
+		}
+		if value == nil {
+			return false, cerr.NewBadRequestError(correlationId, "NULL_FIELD", "Field "+name+" cannot be set to nil").
+				WithDetails("field", name)
+		}
+
+		// The value is converted into the field type, so it is stored the same way as by Store
+		fieldValue := structValue.FieldByIndex(index)
+		if err := setHashValue(value, fieldValue); err != nil {
+			return false, cerr.NewBadRequestError(correlationId, "WRONG_FIELD_TYPE", "Field "+name+" cannot be set to a value of type "+reflect.TypeOf(value).String()).
+				WithDetails("field", name).WithDetails("type", fieldValue.Type().String()).WithCause(err)
+		}
+		data, ok, err := encodeHashValue(fieldValue)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, cerr.NewBadRequestError(correlationId, "NULL_FIELD", "Field "+name+" cannot be set to nil").
+				WithDetails("field", name)
+		}
+		args = append(args, name, data)
+	}
+
+	result, err := c.scripts.Run(ctx, correlationId, updateFieldsScriptName, []string{key}, args...).Int64()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+)
+
+// A number of elements requested from Redis in a single scan or range step.
+const scanBatchSize = 100
+
+// redisCollection is a common base of typed collections stored in a single Redis key.
+// It reuses the connection lifecycle and the value codec of RedisCache.
+type redisCollection[V any] struct {
+	cache *RedisCache[V]
+
+	key string
+	ttl int64
+}
+
+func newRedisCollection[V any](key string) *redisCollection[V] {
+	return &redisCollection[V]{
+		cache: NewRedisCache[V](),
+		key:   key,
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *redisCollection[V]) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.cache.Configure(ctx, config)
+
+	c.key = config.GetAsStringWithDefault("key", c.key)
+	c.ttl = config.GetAsLongWithDefault("options.ttl", c.ttl)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *redisCollection[V]) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.cache.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *redisCollection[V]) IsOpen() bool {
+	return c.cache.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *redisCollection[V]) Open(ctx context.Context, correlationId string) error {
+	if c.key == "" {
+		return cerr.NewConfigError(correlationId, "NO_KEY", "Collection key is not defined")
+	}
+	return c.cache.Open(ctx, correlationId)
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *redisCollection[V]) Close(ctx context.Context, correlationId string) error {
+	return c.cache.Close(ctx, correlationId)
+}
+
+// GetKey method are gets the Redis key of the collection.
+func (c *redisCollection[V]) GetKey() string {
+	return c.key
+}
+
+// Clear method are removes the collection with all its elements.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: error or nil for success.
+func (c *redisCollection[V]) Clear(ctx context.Context, correlationId string) error {
+	state, err := c.cache.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+	return c.cache.client.Del(c.key).Err()
+}
+
+func (c *redisCollection[V]) encode(value V) (string, error) {
+	return c.cache.convertor.ToJson(value)
+}
+
+func (c *redisCollection[V]) decode(data string) (V, error) {
+	return c.cache.convertor.FromJson(data)
+}
+
+func (c *redisCollection[V]) encodeAll(values []V) ([]any, error) {
+	result := make([]any, len(values))
+	for i, value := range values {
+		data, err := c.encode(value)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = data
+	}
+	return result, nil
+}
+
+func (c *redisCollection[V]) decodeAll(data []string) ([]V, error) {
+	result := make([]V, len(data))
+	for i, item := range data {
+		value, err := c.decode(item)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// write executes commands that change the collection in a transaction
+// and refreshes the expiration timeout of the collection when it is configured.
+func (c *redisCollection[V]) write(correlationId string, fn func(pipe redis.Pipeliner)) error {
+	state, err := c.cache.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	_, err = c.cache.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		fn(pipe)
+		if c.ttl > 0 {
+			pipe.PExpire(c.key, time.Duration(c.ttl)*time.Millisecond)
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,239 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-redis/redis"
+)
+
+/*
+RedisList is a distributed list that stores elements in a Redis list.
+Elements are serialized with the same codec as in RedisCache.
+
+Configuration parameters:
+
+  - key:                     (optional) Redis key of the list (default: the name passed to the constructor)
+  - options:
+    - ttl:                   expiration timeout of the whole list in milliseconds refreshed on every change, 0 to keep forever (default: 0)
+
+Connection, credential and client options are the same as in RedisCache.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    tasks := NewRedisList[string]("tasks");
+    tasks.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = tasks.Open(ctx, "123")
+      ...
+
+    err = tasks.Push(ctx, "123", "task1", "task2")
+    task, ok, err := tasks.PopFront(ctx, "123")
+    fmt.Println(task)     // Result: "task1"
+*/
+type RedisList[T any] struct {
+	*redisCollection[T]
+}
+
+// NewRedisList method are creates a new instance of the list.
+// Parameters:
+//   - key    a Redis key of the list.
+func NewRedisList[T any](key string) *RedisList[T] {
+	return &RedisList[T]{
+		redisCollection: newRedisCollection[T](key),
+	}
+}
+
+// Push method are appends elements to the end of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - values            elements to append.
+// Returns: error or nil for success.
+func (c *RedisList[T]) Push(ctx context.Context, correlationId string, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	data, err := c.encodeAll(values)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.RPush(c.key, data...)
+	})
+}
+
+// PushFront method are inserts elements at the beginning of the list.
+// The elements are inserted one after another, so the last one becomes the first element.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - values            elements to insert.
+// Returns: error or nil for success.
+func (c *RedisList[T]) PushFront(ctx context.Context, correlationId string, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	data, err := c.encodeAll(values)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.LPush(c.key, data...)
+	})
+}
+
+// Pop method are removes and returns the last element of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: the element, false if the list is empty, or error.
+func (c *RedisList[T]) Pop(ctx context.Context, correlationId string) (value T, ok bool, err error) {
+	return c.pop(correlationId, func(pipe redis.Pipeliner) *redis.StringCmd {
+		return pipe.RPop(c.key)
+	})
+}
+
+// PopFront method are removes and returns the first element of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: the element, false if the list is empty, or error.
+func (c *RedisList[T]) PopFront(ctx context.Context, correlationId string) (value T, ok bool, err error) {
+	return c.pop(correlationId, func(pipe redis.Pipeliner) *redis.StringCmd {
+		return pipe.LPop(c.key)
+	})
+}
+
+// Get method are gets an element by its index. Negative indexes are counted from the end of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - index             an index of the element.
+// Returns: the element, false if the index is out of range, or error.
+func (c *RedisList[T]) Get(ctx context.Context, correlationId string, index int64) (value T, ok bool, err error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return value, false, err
+	}
+	return c.decodeResult(c.cache.client.LIndex(c.key, index))
+}
+
+// Set method are replaces an element by its index. Negative indexes are counted from the end of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - index             an index of the element.
+//   - value             a new element.
+// Returns: error or nil for success. The error is returned when the index is out of range.
+func (c *RedisList[T]) Set(ctx context.Context, correlationId string, index int64, value T) error {
+	data, err := c.encode(value)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.LSet(c.key, index, data)
+	})
+}
+
+// Len method are gets a number of elements in the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: a number of elements or error.
+func (c *RedisList[T]) Len(ctx context.Context, correlationId string) (int64, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return 0, err
+	}
+	return c.cache.client.LLen(c.key).Result()
+}
+
+// Slice method are gets elements between start and stop indexes inclusive.
+// Negative indexes are counted from the end of the list.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - start             an index of the first element.
+//   - stop              an index of the last element.
+// Returns: a list of elements or error.
+func (c *RedisList[T]) Slice(ctx context.Context, correlationId string, start int64, stop int64) ([]T, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return nil, err
+	}
+
+	items, err := c.cache.client.LRange(c.key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeAll(items)
+}
+
+// Range method are iterates over elements of the list in batches until the function returns false.
+// Elements changed during the iteration may be missed or returned more than once.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - fn                a function called for every element with its index.
+// Returns: error or nil for success.
+func (c *RedisList[T]) Range(ctx context.Context, correlationId string, fn func(index int64, value T) bool) error {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return err
+	}
+
+	for start := int64(0); ; start += scanBatchSize {
+		items, err := c.cache.client.LRange(c.key, start, start+scanBatchSize-1).Result()
+		if err != nil {
+			return err
+		}
+
+		for i, item := range items {
+			value, err := c.decode(item)
+			if err != nil {
+				return err
+			}
+			if !fn(start+int64(i), value) {
+				return nil
+			}
+		}
+
+		if len(items) < scanBatchSize || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// pop removes an element in a transaction that refreshes the expiration timeout of the list.
+func (c *RedisList[T]) pop(correlationId string, fn func(pipe redis.Pipeliner) *redis.StringCmd) (value T, ok bool, err error) {
+	var cmd *redis.StringCmd
+	err = c.write(correlationId, func(pipe redis.Pipeliner) {
+		cmd = fn(pipe)
+	})
+	// An empty list is reported by the pop command
+	if err != nil && err != redis.Nil {
+		return value, false, err
+	}
+	return c.decodeResult(cmd)
+}
+
+func (c *RedisList[T]) decodeResult(cmd *redis.StringCmd) (value T, ok bool, err error) {
+	data, err := cmd.Result()
+	if err == redis.Nil {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+
+	value, err = c.decode(data)
+	return value, err == nil, err
+}
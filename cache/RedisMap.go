@@ -0,0 +1,250 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-redis/redis"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+)
+
+/*
+RedisMap is a distributed map that stores entries in a Redis hash.
+Values are serialized with the same codec as in RedisCache. String keys are stored as is,
+other keys are serialized as JSON.
+
+Configuration parameters:
+
+  - key:                     (optional) Redis key of the hash (default: the name passed to the constructor)
+  - options:
+    - ttl:                   expiration timeout of the whole map in milliseconds refreshed on every change, 0 to keep forever (default: 0)
+
+Connection, credential and client options are the same as in RedisCache.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    users := NewRedisMap[string, int]("users:age");
+    users.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = users.Open(ctx, "123")
+      ...
+
+    err = users.Put(ctx, "123", "john", 25)
+    age, ok, err := users.Get(ctx, "123", "john")
+    fmt.Println(age)     // Result: 25
+
+    err = users.Range(ctx, "123", func(name string, age int) bool {
+      fmt.Println(name, age)
+      return true
+    })
+*/
+type RedisMap[K comparable, V any] struct {
+	*redisCollection[V]
+	keyConvertor cconv.IJSONEngine[K]
+}
+
+// NewRedisMap method are creates a new instance of the map.
+// Parameters:
+//   - key    a Redis key of the hash.
+func NewRedisMap[K comparable, V any](key string) *RedisMap[K, V] {
+	return &RedisMap[K, V]{
+		redisCollection: newRedisCollection[V](key),
+		keyConvertor:    cconv.NewDefaultCustomTypeJsonConvertor[K](),
+	}
+}
+
+func (c *RedisMap[K, V]) encodeKey(key K) (string, error) {
+	if value, ok := any(key).(string); ok {
+		return value, nil
+	}
+	return c.keyConvertor.ToJson(key)
+}
+
+func (c *RedisMap[K, V]) decodeKey(field string) (K, error) {
+	var key K
+	if _, ok := any(key).(string); ok {
+		return any(field).(K), nil
+	}
+	return c.keyConvertor.FromJson(field)
+}
+
+// Get method are gets a value by its key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key of the entry.
+// Returns: the value, true if the entry exists, or error.
+func (c *RedisMap[K, V]) Get(ctx context.Context, correlationId string, key K) (value V, ok bool, err error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return value, false, err
+	}
+
+	field, err := c.encodeKey(key)
+	if err != nil {
+		return value, false, err
+	}
+
+	data, err := c.cache.client.HGet(c.key, field).Result()
+	if err == redis.Nil {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+
+	value, err = c.decode(data)
+	return value, err == nil, err
+}
+
+// Put method are sets a value by its key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key of the entry.
+//   - value             a value to set.
+// Returns: error or nil for success.
+func (c *RedisMap[K, V]) Put(ctx context.Context, correlationId string, key K, value V) error {
+	field, err := c.encodeKey(key)
+	if err != nil {
+		return err
+	}
+	data, err := c.encode(value)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.HSet(c.key, field, data)
+	})
+}
+
+// PutAll method are sets multiple entries at once.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - entries           entries to set.
+// Returns: error or nil for success.
+func (c *RedisMap[K, V]) PutAll(ctx context.Context, correlationId string, entries map[K]V) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]any, len(entries))
+	for key, value := range entries {
+		field, err := c.encodeKey(key)
+		if err != nil {
+			return err
+		}
+		if fields[field], err = c.encode(value); err != nil {
+			return err
+		}
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.HMSet(c.key, fields)
+	})
+}
+
+// Delete method are removes entries by their keys.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - keys              keys of the entries to remove.
+// Returns: error or nil for success.
+func (c *RedisMap[K, V]) Delete(ctx context.Context, correlationId string, keys ...K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		field, err := c.encodeKey(key)
+		if err != nil {
+			return err
+		}
+		fields[i] = field
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.HDel(c.key, fields...)
+	})
+}
+
+// Contains method are checks if the map has an entry with the key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key of the entry.
+// Returns: true if the entry exists or error.
+func (c *RedisMap[K, V]) Contains(ctx context.Context, correlationId string, key K) (bool, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return false, err
+	}
+
+	field, err := c.encodeKey(key)
+	if err != nil {
+		return false, err
+	}
+	return c.cache.client.HExists(c.key, field).Result()
+}
+
+// Len method are gets a number of entries in the map.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: a number of entries or error.
+func (c *RedisMap[K, V]) Len(ctx context.Context, correlationId string) (int64, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return 0, err
+	}
+	return c.cache.client.HLen(c.key).Result()
+}
+
+// Range method are iterates over entries of the map with HSCAN until the function returns false.
+// Entries changed during the iteration may be missed or returned more than once.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - fn                a function called for every entry.
+// Returns: error or nil for success.
+func (c *RedisMap[K, V]) Range(ctx context.Context, correlationId string, fn func(key K, value V) bool) error {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return err
+	}
+
+	var cursor uint64
+	for {
+		items, next, err := c.cache.client.HScan(c.key, cursor, "", scanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+
+		// HSCAN returns fields followed by their values
+		for i := 0; i+1 < len(items); i += 2 {
+			key, err := c.decodeKey(items[i])
+			if err != nil {
+				return err
+			}
+			value, err := c.decode(items[i+1])
+			if err != nil {
+				return err
+			}
+			if !fn(key, value) {
+				return nil
+			}
+		}
+
+		cursor = next
+		if cursor == 0 || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
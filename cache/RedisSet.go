@@ -0,0 +1,204 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-redis/redis"
+)
+
+/*
+RedisSet is a distributed set that stores unique elements in a Redis set.
+Elements are serialized with the same codec as in RedisCache, so equal elements
+must have the same JSON representation.
+
+Configuration parameters:
+
+  - key:                     (optional) Redis key of the set (default: the name passed to the constructor)
+  - options:
+    - ttl:                   expiration timeout of the whole set in milliseconds refreshed on every change, 0 to keep forever (default: 0)
+
+Connection, credential and client options are the same as in RedisCache.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    tags := NewRedisSet[string]("tags");
+    tags.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = tags.Open(ctx, "123")
+      ...
+
+    err = tags.Add(ctx, "123", "red", "green", "red")
+    count, err := tags.Len(ctx, "123")
+    fmt.Println(count)     // Result: 2
+*/
+type RedisSet[T any] struct {
+	*redisCollection[T]
+}
+
+// NewRedisSet method are creates a new instance of the set.
+// Parameters:
+//   - key    a Redis key of the set.
+func NewRedisSet[T any](key string) *RedisSet[T] {
+	return &RedisSet[T]{
+		redisCollection: newRedisCollection[T](key),
+	}
+}
+
+// Add method are adds elements to the set. Elements that already exist are ignored.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - values            elements to add.
+// Returns: error or nil for success.
+func (c *RedisSet[T]) Add(ctx context.Context, correlationId string, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	data, err := c.encodeAll(values)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.SAdd(c.key, data...)
+	})
+}
+
+// Remove method are removes elements from the set.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - values            elements to remove.
+// Returns: error or nil for success.
+func (c *RedisSet[T]) Remove(ctx context.Context, correlationId string, values ...T) error {
+	if len(values) == 0 {
+		return nil
+	}
+	data, err := c.encodeAll(values)
+	if err != nil {
+		return err
+	}
+
+	return c.write(correlationId, func(pipe redis.Pipeliner) {
+		pipe.SRem(c.key, data...)
+	})
+}
+
+// Contains method are checks if the element belongs to the set.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - value             an element to check.
+// Returns: true if the element exists or error.
+func (c *RedisSet[T]) Contains(ctx context.Context, correlationId string, value T) (bool, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return false, err
+	}
+
+	data, err := c.encode(value)
+	if err != nil {
+		return false, err
+	}
+	return c.cache.client.SIsMember(c.key, data).Result()
+}
+
+// Pop method are removes and returns a random element of the set.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: the element, false if the set is empty, or error.
+func (c *RedisSet[T]) Pop(ctx context.Context, correlationId string) (value T, ok bool, err error) {
+	var cmd *redis.StringCmd
+	err = c.write(correlationId, func(pipe redis.Pipeliner) {
+		cmd = pipe.SPop(c.key)
+	})
+	// An empty set is reported by the pop command
+	if err != nil && err != redis.Nil {
+		return value, false, err
+	}
+
+	data, err := cmd.Result()
+	if err == redis.Nil {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+
+	value, err = c.decode(data)
+	return value, err == nil, err
+}
+
+// Len method are gets a number of elements in the set.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: a number of elements or error.
+func (c *RedisSet[T]) Len(ctx context.Context, correlationId string) (int64, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return 0, err
+	}
+	return c.cache.client.SCard(c.key).Result()
+}
+
+// Members method are gets all elements of the set.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: a list of elements in no particular order or error.
+func (c *RedisSet[T]) Members(ctx context.Context, correlationId string) ([]T, error) {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return nil, err
+	}
+
+	items, err := c.cache.client.SMembers(c.key).Result()
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeAll(items)
+}
+
+// Range method are iterates over elements of the set with SSCAN until the function returns false.
+// Elements changed during the iteration may be missed or returned more than once.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - fn                a function called for every element.
+// Returns: error or nil for success.
+func (c *RedisSet[T]) Range(ctx context.Context, correlationId string, fn func(value T) bool) error {
+	if state, err := c.cache.checkOpened(correlationId); !state {
+		return err
+	}
+
+	var cursor uint64
+	for {
+		items, next, err := c.cache.client.SScan(c.key, cursor, "", scanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			value, err := c.decode(item)
+			if err != nil {
+				return err
+			}
+			if !fn(value) {
+				return nil
+			}
+		}
+
+		cursor = next
+		if cursor == 0 || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	cconfig "github.com/pip-services3-gox/pip-services3-components-gox/config"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisConfigReader is a config reader that reads configuration parameters from Redis in-memory database.
+The configuration is kept in a hash with a field per parameter, e.g. "connection.host",
+or in a string key with a JSON object. Values are parameterized with mustache templates.
+
+Change listeners are notified when the key is changed. The notifications rely on Redis keyspace
+notifications that must be enabled on the server with the "K" flag and the events of the key type
+(e.g. notify-keyspace-events "Kgh$") or by the configure_notifications option.
+The option adds only the missing flags and keeps the flags used by other clients.
+
+Configuration parameters:
+
+  - key:                     key with the configuration in Redis (default: "config")
+  - parameters:              this entire section is used as template parameters
+  - options:
+    - configure_notifications: enables keyspace notifications on the server when opened, if CONFIG command is allowed (default: false)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    reader := NewRedisConfigReader();
+    reader.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "key", "myservice:config",
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = reader.Open(ctx, "123")
+      ...
+
+    reader.AddChangeListener(ctx, listener)
+
+    config, err := reader.ReadConfig(ctx, "123", cconf.NewConfigParamsFromTuples("env", "prod"))
+*/
+type RedisConfigReader struct {
+	*cconfig.ConfigReader
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	key                    string
+	configureNotifications bool
+
+	listeners []crun.INotifiable
+	pubsub    *redis.PubSub
+	done      chan struct{}
+	mtx       sync.Mutex
+}
+
+// NewRedisConfigReader method are creates a new instance of the config reader.
+func NewRedisConfigReader() *RedisConfigReader {
+	return &RedisConfigReader{
+		ConfigReader: cconfig.NewConfigReader(),
+		connection:   rconnect.NewRedisConnection(),
+		logger:       *clog.NewCompositeLogger(),
+		key:          "config",
+		listeners:    make([]crun.INotifiable, 0),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisConfigReader) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.ConfigReader.Configure(ctx, config)
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.key = config.GetAsStringWithDefault("key", c.key)
+	c.configureNotifications = config.GetAsBooleanWithDefault("options.configure_notifications", c.configureNotifications)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisConfigReader) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisConfigReader) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component and subscribes to changes of the configuration key.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisConfigReader) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.pubsub != nil {
+		return nil
+	}
+
+	err := c.connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	if c.configureNotifications {
+		c.enableNotifications(ctx, correlationId)
+	}
+
+	channel := "__keyspace@" + strconv.Itoa(c.connection.GetDbNum()) + "__:" + c.key
+	c.pubsub = c.connection.GetClient().Subscribe(channel)
+	c.done = make(chan struct{})
+	go c.listen(correlationId, c.pubsub.Channel(), c.done)
+
+	return nil
+}
+
+// enableNotifications adds the flags required by the reader to keyspace notifications enabled on the server.
+// Flags used by other clients are kept. CONFIG command is often disabled on managed servers,
+// so failures are only logged and the notifications shall be enabled by the server configuration.
+func (c *RedisConfigReader) enableNotifications(ctx context.Context, correlationId string) {
+	client := c.connection.GetClient()
+
+	values, err := client.ConfigGet("notify-keyspace-events").Result()
+	if err != nil || len(values) < 2 {
+		c.logger.Warn(ctx, correlationId, "Failed to read keyspace notifications settings: %v", err)
+		return
+	}
+
+	current, _ := values[1].(string)
+	flags, changed := addNotificationFlags(current, notificationFlags)
+	if !changed {
+		return
+	}
+
+	err = client.ConfigSet("notify-keyspace-events", flags).Err()
+	if err != nil {
+		c.logger.Warn(ctx, correlationId, "Failed to enable keyspace notifications: %v", err)
+	}
+}
+
+// Flags of keyspace notifications required to track changes of hash and string keys.
+const notificationFlags = "Kgh$"
+
+// addNotificationFlags adds missing flags to the current notify-keyspace-events value.
+// The "A" flag is an alias for all event classes, so the classes it covers are not added.
+// It returns the new value and true when any flag was missing.
+func addNotificationFlags(current string, required string) (string, bool) {
+	covered := current
+	if strings.Contains(current, "A") {
+		covered += "g$lshzxet"
+	}
+
+	result := current
+	for _, flag := range required {
+		if !strings.ContainsRune(covered, flag) {
+			result += string(flag)
+		}
+	}
+	return result, result != current
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisConfigReader) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	pubsub, done := c.pubsub, c.done
+	c.pubsub = nil
+	c.done = nil
+	c.mtx.Unlock()
+
+	if pubsub != nil {
+		pubsub.Close()
+		<-done
+	}
+
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisConfigReader) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReadConfig method are reads configuration from the hash or JSON key and parameterizes it with given values.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - parameters        values to parameters the configuration or null to skip parameterization.
+// Returns: configuration parameters or error.
+func (c *RedisConfigReader) ReadConfig(ctx context.Context, correlationId string,
+	parameters *cconf.ConfigParams) (*cconf.ConfigParams, error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	client := c.connection.GetClient()
+	kind, err := client.Type(c.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "hash":
+		values, err := client.HGetAll(c.key).Result()
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			values[key], err = c.Parameterize(value, parameters)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return cconf.NewConfigParams(values), nil
+	case "string":
+		data, err := client.Get(c.key).Result()
+		if err == redis.Nil {
+			return cconf.NewEmptyConfigParams(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err = c.Parameterize(data, parameters)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := cconv.JsonConverter.ToNullableMap(data)
+		if !ok {
+			return nil, cerr.NewConfigError(correlationId, "WRONG_CONFIG", "Configuration "+c.key+" is not a valid JSON object").
+				WithDetails("key", c.key)
+		}
+		return cconf.NewConfigParamsFromValue(value), nil
+	case "none":
+		return nil, cerr.NewConfigError(correlationId, "NO_CONFIG", "Configuration "+c.key+" is not found").
+			WithDetails("key", c.key)
+	default:
+		return nil, cerr.NewConfigError(correlationId, "WRONG_CONFIG", "Configuration "+c.key+" has unsupported type "+kind).
+			WithDetails("key", c.key)
+	}
+}
+
+// AddChangeListener method are adds a listener that will be notified when configuration is changed.
+// Parameters:
+//   - ctx context.Context
+//   - listener      a listener to be added.
+func (c *RedisConfigReader) AddChangeListener(ctx context.Context, listener crun.INotifiable) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.listeners = append(c.listeners, listener)
+}
+
+// RemoveChangeListener method are removes a previously added change listener.
+// Parameters:
+//   - ctx context.Context
+//   - listener      a listener to be removed.
+func (c *RedisConfigReader) RemoveChangeListener(ctx context.Context, listener crun.INotifiable) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for i, l := range c.listeners {
+		if l == listener {
+			c.listeners = append(c.listeners[:i], c.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *RedisConfigReader) listen(correlationId string, messages <-chan *redis.Message, done chan struct{}) {
+	defer close(done)
+
+	ctx := context.Background()
+	for message := range messages {
+		c.logger.Debug(ctx, correlationId, "Configuration %s changed by %s", c.key, message.Payload)
+
+		c.mtx.Lock()
+		listeners := append([]crun.INotifiable{}, c.listeners...)
+		c.mtx.Unlock()
+
+		args := crun.NewParametersFromTuples("key", c.key, "event", message.Payload)
+		for _, listener := range listeners {
+			listener.Notify(ctx, correlationId, args)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package connect
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	cauth "github.com/pip-services3-gox/pip-services3-components-gox/auth"
+	ccon "github.com/pip-services3-gox/pip-services3-components-gox/connect"
+)
+
+/*
+RedisConnection is a connection to Redis in-memory database that is shared by Redis components.
+It resolves connection and credential parameters and opens a single or cluster client.
+Components built on other Redis clients, e.g. locks, resolve the server address with ResolveAddress.
+
+Configuration parameters:
+
+  - connection(s):
+    - discovery_key:         (optional) a key to retrieve the connection from IDiscovery
+    - host:                  host name or IP address
+    - port:                  port number
+    - uri:                   resource URI or connection string with all parameters in it
+  - credential(s):
+    - store_key:             key to retrieve parameters from credential store
+    - username:              user name (currently is not used)
+    - password:              user password
+  - options:
+    - retries:               number of retries (default: 3)
+    - timeout:               connection timeout in milliseconds (default: 30 seconds)
+    - db_num:                database number in Redis  (default 0)
+    - cluster:            	 enable redis cluster
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    connection := NewRedisConnection();
+    connection.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = connection.Open(ctx, "123")
+      ...
+
+    err = connection.GetClient().Set("key1", "ABC", 0).Err()
+*/
+type RedisConnection struct {
+	connectionResolver *ccon.ConnectionResolver
+	credentialResolver *cauth.CredentialResolver
+
+	timeout   int
+	retries   int
+	dbNum     int
+	isCluster bool
+
+	client redis.UniversalClient
+}
+
+// NewRedisConnection method are creates a new instance of this connection.
+func NewRedisConnection() *RedisConnection {
+	return &RedisConnection{
+		connectionResolver: ccon.NewEmptyConnectionResolver(),
+		credentialResolver: cauth.NewEmptyCredentialResolver(),
+		timeout:            30000,
+		retries:            3,
+		dbNum:              0,
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// 	 - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisConnection) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connectionResolver.Configure(ctx, config)
+	c.credentialResolver.Configure(ctx, config)
+
+	c.timeout = config.GetAsIntegerWithDefault("options.timeout", c.timeout)
+	c.retries = config.GetAsIntegerWithDefault("options.retries", c.retries)
+	c.dbNum = config.GetAsIntegerWithDefault("options.db_num", c.dbNum)
+	if c.dbNum > 15 || c.dbNum < 0 {
+		c.dbNum = 0
+	}
+	c.isCluster = config.GetAsBooleanWithDefault("options.cluster", c.isCluster)
+}
+
+// Sets references to dependent components.
+//	 - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisConnection) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connectionResolver.SetReferences(ctx, references)
+	c.credentialResolver.SetReferences(ctx, references)
+}
+
+// Checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisConnection) IsOpen() bool {
+	return c.client != nil
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisConnection) Open(ctx context.Context, correlationId string) error {
+	address, password, err := c.ResolveAddress(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	var client redis.UniversalClient
+	if c.isCluster {
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:       []string{address},
+			Password:    password,
+			DialTimeout: time.Duration(c.timeout) * time.Millisecond,
+			MaxRetries:  c.retries,
+		})
+	} else {
+		client = redis.NewClient(&redis.Options{
+			Addr:        address,
+			Password:    password,
+			DialTimeout: time.Duration(c.timeout) * time.Millisecond,
+			DB:          c.dbNum,
+			MaxRetries:  c.retries,
+		})
+	}
+
+	if err = client.Ping().Err(); err != nil {
+		client.Close()
+		return cerr.NewConnectionError(correlationId, "CONNECT_FAILED", "Connection to Redis failed").
+			WithCause(err)
+	}
+
+	c.client = client
+	return nil
+}
+
+// ResolveAddress method are resolves the address of Redis server and the password from connection and credential parameters.
+// It lets components that use other Redis clients share the same configuration.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: the configured URI or host:port address, the password or error.
+func (c *RedisConnection) ResolveAddress(ctx context.Context, correlationId string) (address string, password string, err error) {
+	connection, err := c.connectionResolver.Resolve(correlationId)
+	if err != nil {
+		return "", "", err
+	}
+
+	if connection == nil {
+		err = cerr.NewConfigError(correlationId, "NO_CONNECTION", "Connection is not configured")
+		return "", "", err
+	}
+
+	credential, err := c.credentialResolver.Lookup(ctx, correlationId)
+	if err != nil {
+		return "", "", err
+	}
+
+	if credential != nil {
+		password = credential.Password()
+	}
+
+	if connection.Uri() != "" {
+		return connection.Uri(), password, nil
+	}
+
+	host := connection.Host()
+	if host == "" {
+		host = "localhost"
+	}
+	port := strconv.FormatInt(int64(connection.Port()), 10)
+	if port == "0" {
+		port = "6379"
+	}
+	return host + ":" + port, password, nil
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisConnection) Close(ctx context.Context, correlationId string) error {
+	if c.client != nil {
+		err := c.client.Close()
+		c.client = nil
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetClient method are gets the client to execute Redis commands.
+// Returns: the opened client or nil if the connection is closed.
+func (c *RedisConnection) GetClient() redis.UniversalClient {
+	return c.client
+}
+
+// IsCluster method are checks if the connection is made to Redis cluster.
+// Returns: true for cluster connections and false otherwise.
+func (c *RedisConnection) IsCluster() bool {
+	return c.isCluster
+}
+
+// GetTimeout method are gets the connection timeout.
+// Returns: the configured timeout in milliseconds.
+func (c *RedisConnection) GetTimeout() int {
+	return c.timeout
+}
+
+// GetDbNum method are gets the number of the database in Redis.
+// Returns: the configured database number.
+func (c *RedisConnection) GetDbNum() int {
+	return c.dbNum
+}
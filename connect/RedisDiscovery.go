@@ -0,0 +1,348 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	ccon "github.com/pip-services3-gox/pip-services3-components-gox/connect"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+)
+
+/*
+RedisDiscovery is a discovery service that keeps connection parameters in Redis in-memory database.
+Service instances register their connections under a discovery key and other components
+resolve them by the same key through discovery_key.
+
+Each registration carries a heartbeat timeout. The component sends heartbeats for its own
+registrations while it is opened, so registrations of dead instances disappear automatically.
+
+Configuration parameters:
+
+  - connection(s):
+    - host:                  host name or IP address
+    - port:                  port number
+    - uri:                   resource URI or connection string with all parameters in it
+  - credential(s):
+    - store_key:             key to retrieve parameters from credential store
+    - username:              user name (currently is not used)
+    - password:              user password
+  - options:
+    - prefix:                prefix of discovery keys in Redis (default: "discovery")
+    - heartbeat_ttl:         timeout in milliseconds after which a registration expires without heartbeats (default: 30000)
+    - heartbeat_interval:    interval in milliseconds to send heartbeats (default: 1/3 of the heartbeat timeout)
+    - retries:               number of retries (default: 3)
+    - timeout:               connection timeout in milliseconds (default: 30 seconds)
+    - db_num:                database number in Redis  (default 0)
+    - cluster:            	 enable redis cluster
+
+References:
+
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    discovery := NewRedisDiscovery();
+    discovery.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = discovery.Open(ctx, "123")
+      ...
+
+    discovery.Register("123", "key1", ccon.NewConnectionParamsFromTuples(
+      "host", "10.1.1.100",
+      "port", 8080,
+    ))
+
+    connection, err := discovery.ResolveOne("123", "key1")
+    fmt.Println(connection.Host())     // Result: "10.1.1.100"
+*/
+type RedisDiscovery struct {
+	connection *RedisConnection
+	logger     clog.CompositeLogger
+
+	prefix            string
+	heartbeatTtl      int64
+	heartbeatInterval int64
+
+	registrations map[string]map[string]string
+	cancel        context.CancelFunc
+	done          chan struct{}
+	mtx           sync.Mutex
+}
+
+// NewRedisDiscovery method are creates a new instance of the discovery service.
+func NewRedisDiscovery() *RedisDiscovery {
+	return &RedisDiscovery{
+		connection:        NewRedisConnection(),
+		logger:            *clog.NewCompositeLogger(),
+		prefix:            "discovery",
+		heartbeatTtl:      30000,
+		heartbeatInterval: 0,
+		registrations:     make(map[string]map[string]string),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisDiscovery) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.prefix = config.GetAsStringWithDefault("options.prefix", c.prefix)
+	c.heartbeatTtl = config.GetAsLongWithDefault("options.heartbeat_ttl", c.heartbeatTtl)
+	if c.heartbeatTtl <= 0 {
+		c.heartbeatTtl = 30000
+	}
+	c.heartbeatInterval = config.GetAsLongWithDefault("options.heartbeat_interval", c.heartbeatInterval)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisDiscovery) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisDiscovery) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component and starts sending heartbeats for own registrations.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisDiscovery) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.done != nil {
+		return nil
+	}
+
+	err := c.connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	heartbeatCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.heartbeat(heartbeatCtx, correlationId, c.done)
+
+	return nil
+}
+
+// Close method are stops sending heartbeats, closes component and frees used resources.
+// Registrations are not removed, they expire after the heartbeat timeout.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisDiscovery) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel = nil
+	c.done = nil
+	c.mtx.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisDiscovery) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisDiscovery) getKeys(key string) []string {
+	// The hash tag keeps both keys in the same cluster slot
+	hashKey := c.prefix + ":{" + key + "}"
+	return []string{hashKey, hashKey + ":heartbeats"}
+}
+
+func (c *RedisDiscovery) getInstanceId(connection *ccon.ConnectionParams) string {
+	if connection.Uri() != "" {
+		return connection.Uri()
+	}
+	return connection.ProtocolWithDefault("") + "://" + connection.Host() + ":" + strconv.Itoa(connection.Port())
+}
+
+// Register method are registers connection parameters into the discovery service.
+// The registration is kept alive by heartbeats until the component is closed.
+// Parameters:
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the connection parameters.
+//   - connection        a connection to be registered.
+// Returns: the registered connection or error.
+func (c *RedisDiscovery) Register(correlationId string, key string,
+	connection *ccon.ConnectionParams) (result *ccon.ConnectionParams, err error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+	if connection == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(connection.Value())
+	if err != nil {
+		return nil, err
+	}
+	id := c.getInstanceId(connection)
+
+	err = registerScript.Run(c.connection.GetClient(), c.getKeys(key), id, string(data), c.heartbeatTtl).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	if c.registrations[key] == nil {
+		c.registrations[key] = make(map[string]string)
+	}
+	c.registrations[key][id] = string(data)
+	c.mtx.Unlock()
+
+	return connection, nil
+}
+
+// Unregister method are removes connection parameters from the discovery service.
+// Parameters:
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the connection parameters.
+//   - connection        a connection to be removed.
+// Returns: error or nil for success.
+func (c *RedisDiscovery) Unregister(correlationId string, key string, connection *ccon.ConnectionParams) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+	if connection == nil {
+		return nil
+	}
+
+	id := c.getInstanceId(connection)
+
+	c.mtx.Lock()
+	delete(c.registrations[key], id)
+	if len(c.registrations[key]) == 0 {
+		delete(c.registrations, key)
+	}
+	c.mtx.Unlock()
+
+	return unregisterScript.Run(c.connection.GetClient(), c.getKeys(key), id).Err()
+}
+
+// ResolveOne method are resolves a single connection parameters by its key.
+// The connection with the most recent heartbeat is returned.
+// Parameters:
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the connection.
+// Returns: a found connection, nil if nothing was registered, or error.
+func (c *RedisDiscovery) ResolveOne(correlationId string, key string) (result *ccon.ConnectionParams, err error) {
+	connections, err := c.ResolveAll(correlationId, key)
+	if err != nil || len(connections) == 0 {
+		return nil, err
+	}
+	return connections[0], nil
+}
+
+// ResolveAll method are resolves all alive connection parameters by their key.
+// Parameters:
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a key to uniquely identify the connections.
+// Returns: a list with found connections or error.
+func (c *RedisDiscovery) ResolveAll(correlationId string, key string) (result []*ccon.ConnectionParams, err error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	res, err := resolveScript.Run(c.connection.GetClient(), c.getKeys(key)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, _ := res.([]any)
+	result = make([]*ccon.ConnectionParams, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var params map[string]string
+		if err = json.Unmarshal([]byte(data), &params); err != nil {
+			c.logger.Warn(context.Background(), correlationId, "Skipped invalid connection registered by key %s", key)
+			continue
+		}
+		result = append(result, ccon.NewConnectionParams(params))
+	}
+	return result, nil
+}
+
+func (c *RedisDiscovery) getHeartbeatInterval() time.Duration {
+	interval := c.heartbeatInterval
+	if interval <= 0 || interval >= c.heartbeatTtl {
+		interval = c.heartbeatTtl / 3
+	}
+	return time.Duration(interval) * time.Millisecond
+}
+
+func (c *RedisDiscovery) heartbeat(ctx context.Context, correlationId string, done chan struct{}) {
+	defer close(done)
+
+	interval := c.getHeartbeatInterval()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		c.mtx.Lock()
+		registrations := make(map[string]map[string]string, len(c.registrations))
+		for key, instances := range c.registrations {
+			registrations[key] = make(map[string]string, len(instances))
+			for id, data := range instances {
+				registrations[key][id] = data
+			}
+		}
+		c.mtx.Unlock()
+
+		for key, instances := range registrations {
+			for id, data := range instances {
+				err := registerScript.Run(c.connection.GetClient(), c.getKeys(key), id, data, c.heartbeatTtl).Err()
+				if err != nil {
+					c.logger.Error(ctx, correlationId, err, "Failed to send heartbeat for %s registered by key %s", id, key)
+				}
+			}
+		}
+	}
+}
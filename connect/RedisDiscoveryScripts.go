@@ -0,0 +1,59 @@
+package connect
+
+import "github.com/go-redis/redis"
+
+// Registrations are kept in a hash with serialized connections by instance ids
+// and a sorted set with the instance ids by their expiration times.
+// Both keys expire when no instance sends heartbeats anymore.
+
+// registerScript adds or refreshes a registration of the instance.
+//   - KEYS[1]  a registrations hash key
+//   - KEYS[2]  a heartbeats key
+//   - ARGV[1]  an instance id
+//   - ARGV[2]  a serialized connection
+//   - ARGV[3]  a heartbeat timeout (time to live) in milliseconds
+// Returns: 1 if the instance was registered and 0 if it was refreshed.
+var registerScript = redis.NewScript(`
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local ttl = tonumber(ARGV[3])
+local added = redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZADD', KEYS[2], now + ttl, ARGV[1])
+if redis.call('PTTL', KEYS[1]) < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+	redis.call('PEXPIRE', KEYS[2], ttl)
+end
+return added
+`)
+
+// resolveScript removes expired registrations and reads the remaining ones
+// starting from the most recent heartbeat.
+//   - KEYS[1]  a registrations hash key
+//   - KEYS[2]  a heartbeats key
+// Returns: an array with serialized connections.
+var resolveScript = redis.NewScript(`
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', now)
+if #expired > 0 then
+	redis.call('HDEL', KEYS[1], unpack(expired))
+	redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', now)
+end
+local ids = redis.call('ZREVRANGE', KEYS[2], 0, -1)
+if #ids == 0 then
+	return {}
+end
+return redis.call('HMGET', KEYS[1], unpack(ids))
+`)
+
+// unregisterScript removes a registration of the instance.
+//   - KEYS[1]  a registrations hash key
+//   - KEYS[2]  a heartbeats key
+//   - ARGV[1]  an instance id
+// Returns: 1 if the instance was removed and 0 if it was not registered.
+var unregisterScript = redis.NewScript(`
+redis.call('ZREM', KEYS[2], ARGV[1])
+return redis.call('HDEL', KEYS[1], ARGV[1])
+`)
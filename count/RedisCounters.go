@@ -0,0 +1,398 @@
+package count
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	ccount "github.com/pip-services3-gox/pip-services3-components-gox/count"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisCounters is a performance counters component that aggregates counters of all service instances in Redis.
+Each instance measures counters in memory and periodically flushes the increments since the previous flush
+into a shared hash of the current time window. Windows expire after the retention timeout.
+Aggregated snapshots for a period of time are read back with ReadSnapshot.
+
+Configuration parameters:
+
+  - interval:                interval in milliseconds to flush current counters measurements (default: 5 mins)
+  - reset_timeout:           timeout in milliseconds to reset the counters. 0 disables the reset (default: 0)
+  - options:
+    - prefix:                prefix of counter keys in Redis (default: "counters")
+    - window:                length of aggregation windows in milliseconds (default: 60000)
+    - retention:             timeout in milliseconds to keep aggregation windows (default: 24 hours)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    counters := NewRedisCounters();
+    counters.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.window", 60000,
+    ));
+
+    err = counters.Open(ctx, "123")
+      ...
+
+    counters.IncrementOne(ctx, "mycomponent.mymethod.calls")
+    timing := counters.BeginTiming(ctx, "mycomponent.mymethod.exec_time")
+    defer timing.EndTiming(ctx)
+
+    err = counters.Dump(ctx)
+
+    snapshot, err := counters.ReadSnapshot(ctx, "123", time.Now().Add(-time.Hour), time.Now())
+*/
+type RedisCounters struct {
+	*ccount.CachedCounters
+	connection *rconnect.RedisConnection
+
+	prefix    string
+	window    int64
+	retention int64
+
+	saved     map[string]ccount.Counter
+	intervals map[string]*intervalStats
+	mtx       sync.Mutex
+}
+
+// intervalStats keeps min and max values measured since the previous flush.
+// In-memory counters keep them for their whole lifetime, so they cannot be used for windows.
+type intervalStats struct {
+	min float64
+	max float64
+}
+
+// NewRedisCounters method are creates a new instance of the performance counters.
+func NewRedisCounters() *RedisCounters {
+	c := &RedisCounters{
+		connection: rconnect.NewRedisConnection(),
+		prefix:     "counters",
+		window:     60000,
+		retention:  24 * 60 * 60 * 1000,
+		saved:      make(map[string]ccount.Counter),
+		intervals:  make(map[string]*intervalStats),
+	}
+	c.CachedCounters = ccount.InheritCacheCounters(c)
+	// Resets would break calculation of increments since the previous flush
+	c.CachedCounters.Configure(context.Background(), cconf.NewConfigParamsFromTuples(
+		ccount.ConfigParameterResetTimeout, 0,
+	))
+	return c
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisCounters) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.CachedCounters.Configure(ctx, config)
+	c.connection.Configure(ctx, config)
+
+	c.prefix = config.GetAsStringWithDefault("options.prefix", c.prefix)
+	c.window = config.GetAsLongWithDefault("options.window", c.window)
+	if c.window <= 0 {
+		c.window = 60000
+	}
+	c.retention = config.GetAsLongWithDefault("options.retention", c.retention)
+	if c.retention < c.window {
+		c.retention = c.window
+	}
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisCounters) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisCounters) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisCounters) Open(ctx context.Context, correlationId string) error {
+	return c.connection.Open(ctx, correlationId)
+}
+
+// Close method are flushes the measured counters, closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisCounters) Close(ctx context.Context, correlationId string) error {
+	var err error
+	if c.IsOpen() {
+		err = c.Dump(ctx)
+	}
+
+	closeErr := c.connection.Close(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func (c *RedisCounters) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisCounters) recordStats(name string, value float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stats, ok := c.intervals[name]
+	if !ok {
+		c.intervals[name] = &intervalStats{min: value, max: value}
+		return
+	}
+	stats.min = math.Min(stats.min, value)
+	stats.max = math.Max(stats.max, value)
+}
+
+// BeginTiming method are begins measurement of execution time interval.
+// Parameters:
+//   - ctx context.Context
+//   - name          a counter name of Interval type.
+// Returns: a timing object to end the measurement.
+func (c *RedisCounters) BeginTiming(ctx context.Context, name string) *ccount.CounterTiming {
+	return ccount.NewCounterTiming(name, c)
+}
+
+// EndTiming method are ends measurement of execution elapsed time and updates specified counter.
+// Parameters:
+//   - ctx context.Context
+//   - name          a counter name of Interval type.
+//   - elapsed       execution elapsed time in milliseconds.
+func (c *RedisCounters) EndTiming(ctx context.Context, name string, elapsed float64) {
+	c.recordStats(name, elapsed)
+	c.CachedCounters.EndTiming(ctx, name, elapsed)
+}
+
+// Stats method are calculates min/average/max statistics based on the current and previous values.
+// Parameters:
+//   - ctx context.Context
+//   - name          a counter name of Statistics type.
+//   - value         a value to update statistics.
+func (c *RedisCounters) Stats(ctx context.Context, name string, value float64) {
+	c.recordStats(name, value)
+	c.CachedCounters.Stats(ctx, name, value)
+}
+
+func (c *RedisCounters) getWindowKey(windowStart int64) string {
+	return c.prefix + ":" + strconv.FormatInt(windowStart, 10)
+}
+
+func (c *RedisCounters) getWindowStart(t time.Time) int64 {
+	millis := t.UnixMilli()
+	return millis - millis%c.window
+}
+
+// Save method are flushes increments of the counters since the previous flush into the current window.
+// It is called by Dump and shall not be called directly.
+// Parameters:
+//   - ctx context.Context
+//   - counters      current counters measurements to be saved.
+// Returns: error or nil for success.
+func (c *RedisCounters) Save(ctx context.Context, counters []ccount.Counter) error {
+	state, err := c.checkOpened("")
+	if !state {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	args := []any{c.retention}
+	for _, counter := range counters {
+		args = append(args, c.getIncrement(counter, c.saved[counter.Name], now)...)
+	}
+	if len(args) == 1 {
+		return nil
+	}
+
+	err = saveScript.Run(c.connection.GetClient(), []string{c.getWindowKey(c.getWindowStart(now))}, args...).Err()
+	if err != nil {
+		return err
+	}
+
+	for _, counter := range counters {
+		c.saved[counter.Name] = counter
+		delete(c.intervals, counter.Name)
+	}
+	return nil
+}
+
+// getIncrement calculates arguments of the save script with changes of the counter since the previous flush.
+func (c *RedisCounters) getIncrement(counter ccount.Counter, saved ccount.Counter, now time.Time) []any {
+	count := counter.Count
+	sum := counter.Average * float64(counter.Count)
+	// Only changes are saved unless the counter was cleared after the previous flush
+	if counter.Count >= saved.Count && counter.Type == saved.Type {
+		count -= saved.Count
+		sum -= saved.Average * float64(saved.Count)
+	}
+
+	updated := count != 0 || counter.Last != saved.Last || !counter.Time.Equal(saved.Time)
+	if !updated {
+		return nil
+	}
+
+	min, max := "", ""
+	if counter.Type == ccount.Interval || counter.Type == ccount.Statistics {
+		if count == 0 {
+			return nil
+		}
+		// Min and max of the previous flushes belong to other windows
+		stats, ok := c.intervals[counter.Name]
+		if !ok {
+			stats = &intervalStats{min: counter.Min, max: counter.Max}
+		}
+		min = cconv.StringConverter.ToString(stats.min)
+		max = cconv.StringConverter.ToString(stats.max)
+	}
+
+	if counter.Type == ccount.Increment {
+		sum = float64(count)
+	}
+
+	lastTime := counter.Time
+	if lastTime.IsZero() {
+		lastTime = now
+	}
+
+	return []any{
+		counter.Name, int(counter.Type), count, sum, min, max,
+		counter.Last, lastTime.UnixMilli(),
+	}
+}
+
+// ReadSnapshot method are reads counters aggregated across all service instances for the period of time.
+// The period is extended to the boundaries of aggregation windows and limited by the retention timeout.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - from              a start of the period.
+//   - to                an end of the period.
+// Returns: a list of aggregated counters sorted by their names or error.
+func (c *RedisCounters) ReadSnapshot(ctx context.Context, correlationId string,
+	from time.Time, to time.Time) ([]ccount.Counter, error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	oldest := time.Now().Add(-time.Duration(c.retention) * time.Millisecond)
+	if from.Before(oldest) {
+		from = oldest
+	}
+
+	pipe := c.connection.GetClient().Pipeline()
+	windows := make([]*redis.StringStringMapCmd, 0)
+	for start := c.getWindowStart(from); start <= to.UnixMilli(); start += c.window {
+		windows = append(windows, pipe.HGetAll(c.getWindowKey(start)))
+	}
+	if len(windows) == 0 {
+		return []ccount.Counter{}, nil
+	}
+
+	_, err = pipe.Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]*ccount.Counter)
+	sums := make(map[string]float64)
+	for _, window := range windows {
+		c.mergeWindow(snapshot, sums, window.Val())
+	}
+
+	counters := make([]ccount.Counter, 0, len(snapshot))
+	for name, counter := range snapshot {
+		if counter.Count > 0 && (counter.Type == ccount.Interval || counter.Type == ccount.Statistics) {
+			counter.Average = sums[name] / float64(counter.Count)
+		}
+		if counter.Min > counter.Max {
+			counter.Min, counter.Max = 0, 0
+		}
+		counters = append(counters, *counter)
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].Name < counters[j].Name })
+	return counters, nil
+}
+
+func (c *RedisCounters) mergeWindow(snapshot map[string]*ccount.Counter, sums map[string]float64, values map[string]string) {
+	for field, value := range values {
+		sep := strings.LastIndex(field, "|")
+		if sep < 0 {
+			continue
+		}
+		name, stat := field[:sep], field[sep+1:]
+
+		counter, ok := snapshot[name]
+		if !ok {
+			counter = &ccount.Counter{Name: name, Min: math.MaxFloat64, Max: -math.MaxFloat64}
+			snapshot[name] = counter
+		}
+
+		switch stat {
+		case "type":
+			counter.Type = ccount.CounterType(cconv.IntegerConverter.ToInteger(value))
+		case "count":
+			counter.Count += cconv.LongConverter.ToLong(value)
+		case "sum":
+			sums[name] += cconv.DoubleConverter.ToDouble(value)
+		case "min":
+			counter.Min = math.Min(counter.Min, cconv.DoubleConverter.ToDouble(value))
+		case "max":
+			counter.Max = math.Max(counter.Max, cconv.DoubleConverter.ToDouble(value))
+		}
+	}
+
+	// The last value is taken from the most recent window
+	for name, counter := range snapshot {
+		timeValue, ok := values[name+"|time"]
+		if !ok {
+			continue
+		}
+		lastTime := time.UnixMilli(cconv.LongConverter.ToLong(timeValue)).UTC()
+		if lastTime.Before(counter.Time) {
+			continue
+		}
+		counter.Time = lastTime
+		counter.Last = cconv.DoubleConverter.ToDouble(values[name+"|last"])
+	}
+}
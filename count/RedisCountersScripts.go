@@ -0,0 +1,39 @@
+package count
+
+import "github.com/go-redis/redis"
+
+// Counters are aggregated in a hash per time window with fields "<name>|<stat>".
+
+// saveScript merges measurements of counters into a time window.
+//   - KEYS[1]  a window key
+//   - ARGV[1]  a retention timeout of the window in milliseconds
+//   - ARGV     groups of 8 values starting from ARGV[2]: a counter name, a counter type,
+//              a count increment, a sum increment, min and max values,
+//              the last value and its time in milliseconds since epoch
+// Returns: a number of merged counters.
+var saveScript = redis.NewScript(`
+local merged = 0
+for i = 2, #ARGV, 8 do
+	local name = ARGV[i]
+	redis.call('HSET', KEYS[1], name .. '|type', ARGV[i + 1])
+	redis.call('HINCRBY', KEYS[1], name .. '|count', ARGV[i + 2])
+	redis.call('HINCRBYFLOAT', KEYS[1], name .. '|sum', ARGV[i + 3])
+	if ARGV[i + 4] ~= '' then
+		local min = tonumber(redis.call('HGET', KEYS[1], name .. '|min'))
+		if not min or tonumber(ARGV[i + 4]) < min then
+			redis.call('HSET', KEYS[1], name .. '|min', ARGV[i + 4])
+		end
+		local max = tonumber(redis.call('HGET', KEYS[1], name .. '|max'))
+		if not max or tonumber(ARGV[i + 5]) > max then
+			redis.call('HSET', KEYS[1], name .. '|max', ARGV[i + 5])
+		end
+	end
+	local time = tonumber(redis.call('HGET', KEYS[1], name .. '|time'))
+	if not time or tonumber(ARGV[i + 7]) >= time then
+		redis.call('HSET', KEYS[1], name .. '|last', ARGV[i + 6], name .. '|time', ARGV[i + 7])
+	end
+	merged = merged + 1
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return merged
+`)
@@ -1,7 +1,17 @@
 package redis
 
 import (
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/auth"
 	_ "github.com/pip-services3-gox/pip-services3-redis-gox/build"
 	_ "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/config"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/count"
 	_ "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/log"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/persistence"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/queues"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/ratelimit"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/scripts"
+	_ "github.com/pip-services3-gox/pip-services3-redis-gox/state"
 )
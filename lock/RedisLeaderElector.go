@@ -0,0 +1,268 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+)
+
+/*
+RedisLeaderElector elects a single leader among service replicas for a named role.
+The leader holds a lock in Redis and periodically renews its lease.
+Other replicas wait for the lock, so they take over as soon as the leader steps down or its lease expires.
+
+Configuration parameters:
+
+  - options:
+    - role:                  a name of the role to campaign for (default: "leader")
+    - lease_timeout:         timeout in milliseconds after which the leadership is lost if not renewed (default: 10000)
+    - renew_timeout:         interval in milliseconds to renew the leadership (default: 1/3 of the lease timeout)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    elector := NewRedisLeaderElector();
+    elector.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.role", "scheduler",
+    ));
+
+    err = elector.Open(ctx, "123")
+      ...
+
+    go func() {
+    	for leader := range elector.LeadershipChanges() {
+    		fmt.Println("Leader:", leader)
+    	}
+    }()
+
+    if elector.IsLeader() {
+    	// Run scheduled work...
+    }
+
+    err = elector.Close(ctx, "123")
+*/
+type RedisLeaderElector struct {
+	lock   *RedisLock
+	logger clog.CompositeLogger
+
+	role         string
+	leaseTimeout int64
+	renewTimeout int64
+
+	leader  int32
+	changes chan bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	mtx     sync.Mutex
+}
+
+// NewRedisLeaderElector method are creates a new instance of this elector.
+func NewRedisLeaderElector() *RedisLeaderElector {
+	return &RedisLeaderElector{
+		lock:         NewRedisLock(),
+		logger:       *clog.NewCompositeLogger(),
+		role:         "leader",
+		leaseTimeout: 10000,
+		renewTimeout: 0,
+		changes:      make(chan bool, 1),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisLeaderElector) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.lock.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.role = config.GetAsStringWithDefault("options.role", c.role)
+	c.leaseTimeout = config.GetAsLongWithDefault("options.lease_timeout", c.leaseTimeout)
+	c.renewTimeout = config.GetAsLongWithDefault("options.renew_timeout", c.renewTimeout)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisLeaderElector) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.lock.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisLeaderElector) IsOpen() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.done != nil
+}
+
+// Open method are opens the component and starts campaigning for the role.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisLeaderElector) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.done != nil {
+		return nil
+	}
+
+	err := c.lock.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	campaignCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.campaign(campaignCtx, correlationId, c.done)
+
+	return nil
+}
+
+// Close method are steps down from the leadership, closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisLeaderElector) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.done == nil {
+		return nil
+	}
+
+	// Wait until the campaign stops using the lock
+	c.cancel()
+	<-c.done
+	c.cancel = nil
+	c.done = nil
+
+	var err error
+	if c.IsLeader() {
+		err = c.lock.ReleaseLock(ctx, correlationId, c.lockKey())
+		c.setLeader(ctx, correlationId, false)
+	}
+
+	closeErr := c.lock.Close(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// IsLeader method are checks if this replica currently holds the leadership.
+// Returns true if the replica is the leader and false otherwise.
+func (c *RedisLeaderElector) IsLeader() bool {
+	return atomic.LoadInt32(&c.leader) == 1
+}
+
+// LeadershipChanges method are gets a channel that receives true when this replica
+// becomes the leader and false when it loses the leadership.
+// The channel keeps only the latest change, so slow readers never block the renewal.
+func (c *RedisLeaderElector) LeadershipChanges() <-chan bool {
+	return c.changes
+}
+
+func (c *RedisLeaderElector) lockKey() string {
+	return "leader:" + c.role
+}
+
+func (c *RedisLeaderElector) getRenewTimeout() time.Duration {
+	renewTimeout := c.renewTimeout
+	if renewTimeout <= 0 || renewTimeout >= c.leaseTimeout {
+		renewTimeout = c.leaseTimeout / 3
+	}
+	return time.Duration(renewTimeout) * time.Millisecond
+}
+
+func (c *RedisLeaderElector) setLeader(ctx context.Context, correlationId string, leader bool) {
+	var value int32
+	if leader {
+		value = 1
+	}
+	if atomic.SwapInt32(&c.leader, value) == value {
+		return
+	}
+
+	if leader {
+		c.logger.Info(ctx, correlationId, "Became the leader for role %s", c.role)
+	} else {
+		c.logger.Info(ctx, correlationId, "Stepped down from the leadership for role %s", c.role)
+	}
+
+	// Replace the unread change with the latest one
+	select {
+	case <-c.changes:
+	default:
+	}
+	c.changes <- leader
+}
+
+func (c *RedisLeaderElector) campaign(ctx context.Context, correlationId string, done chan struct{}) {
+	defer close(done)
+
+	key := c.lockKey()
+	renewTimeout := c.getRenewTimeout()
+
+	for ctx.Err() == nil {
+		if c.IsLeader() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(renewTimeout):
+			}
+
+			renewed, err := c.lock.ExtendLock(ctx, correlationId, key, c.leaseTimeout)
+			if err != nil {
+				c.logger.Error(ctx, correlationId, err, "Failed to renew the leadership for role %s", c.role)
+			}
+			if !renewed {
+				c.setLeader(ctx, correlationId, false)
+			}
+			continue
+		}
+
+		// Wait for the current leader to step down
+		err := c.lock.AcquireLock(ctx, correlationId, key, c.leaseTimeout, renewTimeout.Milliseconds())
+		if err == nil {
+			c.setLeader(ctx, correlationId, true)
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Pause on failures other than the acquisition timeout
+		if appErr, ok := err.(*cerr.ApplicationError); !ok || appErr.Code != "LOCK_TIMEOUT" {
+			c.logger.Error(ctx, correlationId, err, "Failed to campaign for role %s", c.role)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(renewTimeout):
+			}
+		}
+	}
+}
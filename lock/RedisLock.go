@@ -2,7 +2,10 @@ package lock
 
 import (
 	"context"
-	"strconv"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -10,13 +13,22 @@ import (
 	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
 	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
-	cauth "github.com/pip-services3-gox/pip-services3-components-gox/auth"
-	ccon "github.com/pip-services3-gox/pip-services3-components-gox/connect"
 	clock "github.com/pip-services3-gox/pip-services3-components-gox/lock"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	rscripts "github.com/pip-services3-gox/pip-services3-redis-gox/scripts"
 )
 
 /*
 RedisLock are distributed lock that is implemented based on Redis in-memory database.
+Released locks are announced over Redis pub/sub, so waiting clients wake up immediately
+and use polling only as a fallback. All waiters of the component share one subscription
+opened with the lock.
+In the fair mode waiters are enqueued and get the lock in the order of their arrival.
+Lock owners leave their host name, process id and acquisition time next to the lock
+to help operators diagnose stuck locks.
+Lua scripts registered in the registry returned by GetScripts run on the lock connection
+and are preloaded when the lock is opened.
 
 Configuration parameters:
 
@@ -30,14 +42,21 @@ Configuration parameters:
     - username:              user name (currently is not used)
     - password:              user password
   - options:
-    - retrytimeout:         timeout in milliseconds to retry lock acquisition. (Default: 100)
+    - retry_timeout:         initial timeout in milliseconds to retry lock acquisition. (Default: 100)
+    - retry_max_timeout:     maximum timeout in milliseconds to retry lock acquisition. (Default: 1000)
+    - retry_multiplier:      multiplier of the retry timeout after each failed attempt. (Default: 2)
+    - retry_jitter:          fraction of the retry timeout that is randomly cut off, from 0 to 1. (Default: 0.5)
     - retries:               number of retries (default: 3)
     - db_num:                database number in Redis  (default 0)
+    - reentrant:             allows the lock owner to acquire the same lock again (default: false)
+    - fair:                  grants the lock to waiters in the order of their arrival (default: false)
+    - queue_timeout:         timeout in milliseconds after which a silent waiter is removed from the queue, at least twice the retry_timeout (default: 3000)
 
 References:
 
 - *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
 - *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
 
 Example:
 	ctx := context.Background()
@@ -60,27 +79,54 @@ Example:
 */
 type RedisLock struct {
 	*clock.Lock
-	connectionResolver *ccon.ConnectionResolver
-	credentialResolver *cauth.CredentialResolver
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	lockId          string
+	reentrant       bool
+	fair            bool
+	queueTimeout    int64
+	retryTimeout    int64
+	retryMaxTimeout int64
+	retryMultiplier float64
+	retryJitter     float64
+	hostname        string
+	pid             int
+
+	client  redis.Conn
+	scripts *rscripts.RedisScripts
+
+	subscriber     *redis.PubSubConn
+	subscriberDone chan struct{}
+	waiters        map[string]map[chan struct{}]bool
+	waitersMtx     sync.Mutex
+}
 
-	lockId  string
-	timeout int
-	retries int
-	dbNum   int
+const lockInfoKeySuffix = ":info"
 
-	client redis.Conn
-}
+// A prefix of pub/sub channels where releases of locks are announced with their keys.
+const releaseChannelPrefix = "lock:released:"
 
 // NewRedisLock method are creates a new instance of this lock.
 func NewRedisLock() *RedisLock {
+	hostname, _ := os.Hostname()
+
 	c := &RedisLock{
-		connectionResolver: ccon.NewEmptyConnectionResolver(),
-		credentialResolver: cauth.NewEmptyCredentialResolver(),
-		lockId:             cdata.IdGenerator.NextLong(),
-		timeout:            30000,
-		retries:            3,
-		dbNum:              0,
-		client:             nil,
+		connection:      rconnect.NewRedisConnection(),
+		logger:          *clog.NewCompositeLogger(),
+		lockId:          cdata.IdGenerator.NextLong(),
+		reentrant:       false,
+		fair:            false,
+		queueTimeout:    3000,
+		retryTimeout:    clock.DefaultRetryTimeout,
+		retryMaxTimeout: 1000,
+		retryMultiplier: 2,
+		retryJitter:     0.5,
+		hostname:        hostname,
+		pid:             os.Getpid(),
+		client:          nil,
+		scripts:         rscripts.NewRedisScripts(),
+		waiters:         make(map[string]map[chan struct{}]bool),
 	}
 	c.Lock = clock.InheritLock(c)
 	return c
@@ -90,14 +136,31 @@ func NewRedisLock() *RedisLock {
 // Parameters:
 //   - config    configuration parameters to be set.
 func (c *RedisLock) Configure(ctx context.Context, config *cconf.ConfigParams) {
-	c.connectionResolver.Configure(ctx, config)
-	c.credentialResolver.Configure(ctx, config)
-
-	c.timeout = config.GetAsIntegerWithDefault("options.timeout", c.timeout)
-	c.retries = config.GetAsIntegerWithDefault("options.retries", c.retries)
-	c.dbNum = config.GetAsIntegerWithDefault("options.db_num", c.dbNum)
-	if c.dbNum > 15 || c.dbNum < 0 {
-		c.dbNum = 0
+	c.Lock.Configure(ctx, config)
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.retryTimeout = config.GetAsLongWithDefault(clock.ConfigParamOptionsRetryTimeout, c.retryTimeout)
+	c.retryMaxTimeout = config.GetAsLongWithDefault("options.retry_max_timeout", c.retryMaxTimeout)
+	if c.retryMaxTimeout < c.retryTimeout {
+		c.retryMaxTimeout = c.retryTimeout
+	}
+	c.retryMultiplier = config.GetAsDoubleWithDefault("options.retry_multiplier", c.retryMultiplier)
+	if c.retryMultiplier < 1 {
+		c.retryMultiplier = 1
+	}
+	c.retryJitter = config.GetAsDoubleWithDefault("options.retry_jitter", c.retryJitter)
+	if c.retryJitter < 0 {
+		c.retryJitter = 0
+	} else if c.retryJitter > 1 {
+		c.retryJitter = 1
+	}
+	c.reentrant = config.GetAsBooleanWithDefault("options.reentrant", c.reentrant)
+	c.fair = config.GetAsBooleanWithDefault("options.fair", c.fair)
+	c.queueTimeout = config.GetAsLongWithDefault("options.queue_timeout", c.queueTimeout)
+	// Waiters refresh their place in the queue twice per queue timeout, not more often than they retry
+	if c.queueTimeout < 2*c.retryTimeout {
+		c.queueTimeout = 2 * c.retryTimeout
 	}
 }
 
@@ -106,8 +169,8 @@ func (c *RedisLock) Configure(ctx context.Context, config *cconf.ConfigParams) {
 //   - ctx context.Context
 //   - references 	references to locate the component dependencies.
 func (c *RedisLock) SetReferences(ctx context.Context, references cref.IReferences) {
-	c.connectionResolver.SetReferences(ctx, references)
-	c.credentialResolver.SetReferences(ctx, references)
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
 }
 
 // IsOpen method are checks if the component is opened.
@@ -122,48 +185,40 @@ func (c *RedisLock) IsOpen() bool {
 // 	- correlationId 	(optional) transaction id to trace execution through call chain.
 // Returns: error or nil no errors occured.
 func (c *RedisLock) Open(ctx context.Context, correlationId string) error {
-	var connection *ccon.ConnectionParams
-	var credential *cauth.CredentialParams
-
-	connection, err := c.connectionResolver.Resolve(correlationId)
-
-	if err == nil && connection == nil {
-		err = cerr.NewConfigError(correlationId, "NO_CONNECTION", "Connection is not configured")
-		return err
-	}
-
-	credential, err = c.credentialResolver.Lookup(ctx, correlationId)
+	client, err := dialConnection(ctx, correlationId, c.connection)
 	if err != nil {
 		return err
 	}
+	c.client = client
+	c.openSubscriber(ctx, correlationId)
 
-	var url, host, port, password string
-	var dialOpts []redis.DialOption = make([]redis.DialOption, 0)
+	c.scripts.SetExecutor(client)
+	return c.scripts.Open(ctx, correlationId)
+}
 
-	dialOpts = append(dialOpts, redis.DialConnectTimeout(time.Duration(c.timeout)*time.Millisecond))
-	dialOpts = append(dialOpts, redis.DialDatabase(c.dbNum))
+// dialConnection dials Redis server with the address and options resolved by the connection.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+//  - connection		a configured connection with connection and credential parameters.
+// Returns: an opened connection or error.
+func dialConnection(ctx context.Context, correlationId string, connection *rconnect.RedisConnection) (redis.Conn, error) {
+	address, password, err := connection.ResolveAddress(ctx, correlationId)
+	if err != nil {
+		return nil, err
+	}
 
-	if credential != nil {
-		password = credential.Password()
-		dialOpts = append(dialOpts, redis.DialPassword(password))
+	dialOpts := []redis.DialOption{
+		redis.DialConnectTimeout(time.Duration(connection.GetTimeout()) * time.Millisecond),
+		redis.DialDatabase(connection.GetDbNum()),
+		redis.DialPassword(password),
 	}
 
-	if connection.Uri() != "" {
-		url = connection.Uri()
-		c.client, err = redis.DialURL(url, dialOpts...)
-	} else {
-		host = connection.Host()
-		if host == "" {
-			host = "localhost"
-		}
-		port = strconv.FormatInt(int64(connection.Port()), 10)
-		if port == "0" {
-			port = "6379"
-		}
-		url = host + ":" + port
-		c.client, err = redis.Dial("tcp", url, dialOpts...)
+	// Configured URIs contain a scheme, while addresses made of host and port do not
+	if strings.Contains(address, "://") {
+		return redis.DialURL(address, dialOpts...)
 	}
-	return err
+	return redis.Dial("tcp", address, dialOpts...)
 }
 
 // Close method are closes component and frees used resources.
@@ -172,6 +227,13 @@ func (c *RedisLock) Open(ctx context.Context, correlationId string) error {
 //  - correlationId 	(optional) transaction id to trace execution through call chain.
 // Retruns: error or nil no errors occured.
 func (c *RedisLock) Close(ctx context.Context, correlationId string) error {
+	c.scripts.Close(ctx, correlationId)
+	if c.subscriber != nil {
+		// Closing the connection stops the listening routine
+		c.subscriber.Close()
+		<-c.subscriberDone
+		c.subscriber = nil
+	}
 	if c.client != nil {
 		err := c.client.Close()
 		c.client = nil
@@ -182,6 +244,11 @@ func (c *RedisLock) Close(ctx context.Context, correlationId string) error {
 	return nil
 }
 
+// GetScripts method are gets a registry of Lua scripts executed on the lock connection.
+func (c *RedisLock) GetScripts() *rscripts.RedisScripts {
+	return c.scripts
+}
+
 func (c *RedisLock) checkOpened(correlationId string) (state bool, err error) {
 	if !c.IsOpen() {
 		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
@@ -200,16 +267,218 @@ func (c *RedisLock) checkOpened(correlationId string) (state bool, err error) {
 //  - ttl               a lock timeout (time to live) in milliseconds.
 // Returns: a lock result or error.
 func (c *RedisLock) TryAcquireLock(ctx context.Context, correlationId string, key string, ttl int64) (result bool, err error) {
+	// A single attempt does not take a place in the queue
+	return c.tryAcquireLock(correlationId, key, ttl, 0)
+}
+
+func (c *RedisLock) tryAcquireLock(correlationId string, key string, ttl int64, queueTimeout int64) (result bool, err error) {
 	state, err := c.checkOpened(correlationId)
 	if !state {
 		return false, err
 	}
 
-	res, err := redis.String(c.client.Do("SET", key, c.lockId, "NX", "PX", ttl))
-	if err != nil && err == redis.ErrNil {
-		return false, nil
+	acquiredAt := time.Now().UnixMilli()
+
+	if c.fair {
+		reentrant := 0
+		if c.reentrant {
+			reentrant = 1
+		}
+		res, err := redis.Int(fairAcquireScript.Do(c.client,
+			key, c.infoKey(key), c.queueKey(key), c.queueTimeoutsKey(key),
+			c.lockId, ttl, c.hostname, c.pid, acquiredAt, queueTimeout, reentrant))
+		return res == 1, err
+	}
+
+	script := acquireScript
+	if c.reentrant {
+		script = reentrantAcquireScript
+	}
+	res, err := redis.Int(script.Do(c.client, key, c.infoKey(key), c.lockId, ttl, c.hostname, c.pid, acquiredAt))
+	return res == 1, err
+}
+
+// AcquireLock method are makes multiple attempts to acquire a lock by its key within give time interval.
+// Between attempts it waits for a release notification and polls the lock only when notifications are missing.
+// Polling intervals grow with a random jitter, and the acquisition stops as soon as the context is cancelled.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to acquire.
+//  - ttl               a lock timeout (time to live) in milliseconds.
+//  - timeout           a lock acquisition timeout in milliseconds.
+// Returns: error or nil for success.
+func (c *RedisLock) AcquireLock(ctx context.Context, correlationId string, key string, ttl int64, timeout int64) error {
+	expireTime := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+
+	if ctx.Err() != nil {
+		return cerr.NewInvalidStateError(
+			correlationId,
+			"LOCK_CANCELLED",
+			"Acquiring lock "+key+" was cancelled",
+		).WithDetails("key", key).WithCause(ctx.Err())
+	}
+
+	locked, err := c.TryAcquireLock(ctx, correlationId, key, ttl)
+	if locked || err != nil {
+		return err
+	}
+
+	released, unsubscribe := c.subscribeRelease(key)
+	defer unsubscribe()
+
+	// In the fair mode the waiter stays in the queue until it gets the lock or gives up
+	var queueTimeout int64
+	if c.fair {
+		queueTimeout = c.queueTimeout
+		defer c.leaveQueue(key)
+	}
+
+	delay := time.Duration(c.retryTimeout) * time.Millisecond
+	for {
+		// Check the lock once more in case it was released before the subscription
+		locked, err = c.tryAcquireLock(correlationId, key, ttl, queueTimeout)
+		if locked || err != nil {
+			return err
+		}
+
+		wait := time.Until(expireTime)
+		if wait <= 0 {
+			break
+		}
+		if jittered := c.jitterRetryDelay(delay); wait > jittered {
+			wait = jittered
+		}
+		// Waiters must refresh their place in the queue before it expires
+		if maxWait := time.Duration(queueTimeout/2) * time.Millisecond; c.fair && wait > maxWait {
+			wait = maxWait
+		}
+
+		select {
+		case <-ctx.Done():
+			return cerr.NewInvalidStateError(
+				correlationId,
+				"LOCK_CANCELLED",
+				"Acquiring lock "+key+" was cancelled",
+			).WithDetails("key", key).WithCause(ctx.Err())
+		case <-released:
+		case <-time.After(wait):
+			delay = c.nextRetryDelay(delay)
+		}
+	}
+
+	return cerr.NewConflictError(
+		correlationId,
+		"LOCK_TIMEOUT",
+		"Acquiring lock "+key+" failed on timeout",
+	).WithDetails("key", key)
+}
+
+func (c *RedisLock) infoKey(key string) string {
+	return key + lockInfoKeySuffix
+}
+
+// jitterRetryDelay randomly shortens the retry delay, so competing clients do not poll in lockstep.
+func (c *RedisLock) jitterRetryDelay(delay time.Duration) time.Duration {
+	return delay - time.Duration(c.retryJitter*rand.Float64()*float64(delay))
+}
+
+// nextRetryDelay increases the retry delay up to the maximum.
+func (c *RedisLock) nextRetryDelay(delay time.Duration) time.Duration {
+	delay = time.Duration(float64(delay) * c.retryMultiplier)
+	if maxDelay := time.Duration(c.retryMaxTimeout) * time.Millisecond; delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func (c *RedisLock) queueKey(key string) string {
+	return key + ":queue"
+}
+
+func (c *RedisLock) queueTimeoutsKey(key string) string {
+	return key + ":queue_timeouts"
+}
+
+// leaveQueue removes the owner from the queue of waiters.
+func (c *RedisLock) leaveQueue(key string) {
+	if c.IsOpen() {
+		c.client.Do("ZREM", c.queueKey(key), c.lockId)
+		c.client.Do("ZREM", c.queueTimeoutsKey(key), c.lockId)
+	}
+}
+
+func (c *RedisLock) releaseChannel(key string) string {
+	return releaseChannelPrefix + key
+}
+
+// openSubscriber opens a separate connection that listens for release notifications of all locks.
+// When the subscription fails waiters fall back to polling.
+func (c *RedisLock) openSubscriber(ctx context.Context, correlationId string) {
+	conn, err := dialConnection(ctx, correlationId, c.connection)
+	if err != nil {
+		c.logger.Warn(ctx, correlationId, "Failed to subscribe to lock releases: %v", err)
+		return
+	}
+
+	subscriber := &redis.PubSubConn{Conn: conn}
+	if err = subscriber.PSubscribe(releaseChannelPrefix + "*"); err != nil {
+		c.logger.Warn(ctx, correlationId, "Failed to subscribe to lock releases: %v", err)
+		conn.Close()
+		return
+	}
+
+	c.subscriber = subscriber
+	c.subscriberDone = make(chan struct{})
+	go c.listenReleases(subscriber, c.subscriberDone)
+}
+
+// listenReleases passes release notifications to the waiters of released locks until the subscription is closed.
+func (c *RedisLock) listenReleases(subscriber *redis.PubSubConn, done chan struct{}) {
+	defer close(done)
+
+	for {
+		switch message := subscriber.Receive().(type) {
+		case redis.Message:
+			// Release scripts publish keys of released locks
+			key := string(message.Data)
+
+			c.waitersMtx.Lock()
+			for notifications := range c.waiters[key] {
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+			c.waitersMtx.Unlock()
+		case error:
+			return
+		}
+	}
+}
+
+// subscribeRelease registers a waiter for release notifications of the lock.
+// When the lock has no subscription the returned channel never fires and acquisition falls back to polling.
+func (c *RedisLock) subscribeRelease(key string) (released <-chan struct{}, unsubscribe func()) {
+	notifications := make(chan struct{}, 1)
+
+	c.waitersMtx.Lock()
+	defer c.waitersMtx.Unlock()
+
+	if c.waiters[key] == nil {
+		c.waiters[key] = make(map[chan struct{}]bool)
+	}
+	c.waiters[key][notifications] = true
+
+	return notifications, func() {
+		c.waitersMtx.Lock()
+		defer c.waitersMtx.Unlock()
+
+		delete(c.waiters[key], notifications)
+		if len(c.waiters[key]) == 0 {
+			delete(c.waiters, key)
+		}
 	}
-	return res == "OK", err
 }
 
 // ReleaseLock method are releases prevously acquired lock by its key.
@@ -223,25 +492,142 @@ func (c *RedisLock) ReleaseLock(ctx context.Context, correlationId string, key s
 		return err
 	}
 
-	// Start transaction on key
-	_, err = c.client.Do("WATCH", key)
+	if c.reentrant {
+		_, err = reentrantReleaseScript.Do(c.client, key, c.infoKey(key), c.lockId, c.releaseChannel(key))
+		return err
+	}
+
+	_, err = releaseScript.Do(c.client, key, c.infoKey(key), c.lockId, c.releaseChannel(key))
+	return err
+}
+
+// ExtendLock method are prolongs a lock held by this owner.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to extend.
+//  - ttl               a new lock timeout (time to live) in milliseconds.
+// Returns: true if the lock was extended, false if it is not held by this owner, or error.
+func (c *RedisLock) ExtendLock(ctx context.Context, correlationId string, key string, ttl int64) (result bool, err error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return false, err
+	}
+
+	res, err := redis.Int(extendScript.Do(c.client, key, c.infoKey(key), c.lockId, ttl))
+	return res == 1, err
+}
+
+// ForceReleaseLock method are releases a lock regardless of its owner.
+// It is intended for operators to break locks left by crashed processes.
+// The release is logged together with the reason and the previous owner.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to release.
+//  - reason            a reason of the forced release.
+// Returns: error or nil for success.
+func (c *RedisLock) ForceReleaseLock(ctx context.Context, correlationId string, key string, reason string) error {
+	info, err := c.GetLockInfo(ctx, correlationId, key)
 	if err != nil {
 		return err
 	}
 
-	// Read and check if lock is the same
-	keyId, err := redis.String(c.client.Do("GET", key))
-	if err != nil && err != redis.ErrNil {
-		c.client.Do("UNWATCH")
+	res, err := redis.Int(forceReleaseScript.Do(c.client, key, c.infoKey(key), c.releaseChannel(key)))
+	if err != nil {
 		return err
 	}
-	// Remove the lock if it matches
-	if keyId == c.lockId {
-		c.client.Send("MULTI")
-		c.client.Send("DEL", key)
-		_, err = c.client.Do("EXEC")
-	} else { // Cancel transaction if it doesn"t match
-		_, err = c.client.Do("UNWATCH")
+
+	if res == 1 && info != nil {
+		c.logger.Warn(ctx, correlationId, "Lock %s was force released from owner %s on host %s (pid %d) acquired at %s: %s",
+			key, info.Owner, info.Hostname, info.Pid, info.AcquiredAt.Format(time.RFC3339), reason)
+	} else if res == 1 {
+		c.logger.Warn(ctx, correlationId, "Lock %s was force released from unknown owner: %s", key, reason)
+	}
+	return nil
+}
+
+// GetLockInfo method are gets information about the owner and remaining timeout of a lock.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key.
+// Returns: the lock information, nil if the lock is not held, or error.
+func (c *RedisLock) GetLockInfo(ctx context.Context, correlationId string, key string) (*RedisLockInfo, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
 	}
-	return err
+
+	values, err := redis.Values(lockInfoScript.Do(c.client, key, c.infoKey(key)))
+	if err == redis.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var owner, hostname string
+	var count, pid int
+	var ttl, acquiredAt int64
+	_, err = redis.Scan(values, &owner, &count, &ttl, &hostname, &pid, &acquiredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &RedisLockInfo{
+		Key:       key,
+		Owner:     owner,
+		HoldCount: count,
+		Ttl:       ttl,
+		Hostname:  hostname,
+		Pid:       pid,
+	}
+	if acquiredAt > 0 {
+		info.AcquiredAt = time.UnixMilli(acquiredAt).UTC()
+	}
+	return info, nil
+}
+
+// ListLocks method are gets information about all held locks with keys matching the pattern.
+// The keys are scanned incrementally, so the method does not block Redis server.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - pattern           a glob-style pattern of lock keys, e.g. "jobs:*".
+// Returns: a list with the lock information or error.
+func (c *RedisLock) ListLocks(ctx context.Context, correlationId string, pattern string) ([]*RedisLockInfo, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	result := make([]*RedisLockInfo, 0)
+	cursor := 0
+	for {
+		values, err := redis.Values(c.client.Do("SCAN", cursor, "MATCH", pattern+lockInfoKeySuffix, "COUNT", 100))
+		if err != nil {
+			return nil, err
+		}
+
+		var keys []string
+		if _, err = redis.Scan(values, &cursor, &keys); err != nil {
+			return nil, err
+		}
+
+		for _, infoKey := range keys {
+			info, err := c.GetLockInfo(ctx, correlationId, strings.TrimSuffix(infoKey, lockInfoKeySuffix))
+			if err != nil {
+				return nil, err
+			}
+			if info != nil {
+				result = append(result, info)
+			}
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+	return result, nil
 }
@@ -0,0 +1,14 @@
+package lock
+
+import "time"
+
+// RedisLockInfo contains information about a lock held in Redis.
+type RedisLockInfo struct {
+	Key        string    `json:"key"`         // A unique lock key
+	Owner      string    `json:"owner"`       // An id of the lock owner
+	Hostname   string    `json:"hostname"`    // A host name of the lock owner
+	Pid        int       `json:"pid"`         // A process id of the lock owner
+	AcquiredAt time.Time `json:"acquired_at"` // A time when the lock was acquired
+	Ttl        int64     `json:"ttl"`         // A remaining lock timeout in milliseconds
+	HoldCount  int       `json:"hold_count"`  // A number of times the lock is held by the owner
+}
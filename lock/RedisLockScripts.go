@@ -0,0 +1,196 @@
+package lock
+
+import "github.com/gomodule/redigo/redis"
+
+// Lock owners leave their metadata in an info hash that expires together with the lock.
+// Acquisition scripts receive the metadata in the following arguments:
+//   - ARGV[1]  an owner id
+//   - ARGV[2]  a lock timeout in milliseconds
+//   - ARGV[3]  a host name of the owner
+//   - ARGV[4]  a process id of the owner
+//   - ARGV[5]  an acquisition time in milliseconds since epoch
+
+// acquireScript acquires an exclusive lock stored as a string with the owner id.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+// Returns: 1 if the lock was acquired and 0 otherwise.
+var acquireScript = redis.NewScript(2, `
+if not redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 0
+end
+redis.call('DEL', KEYS[2])
+redis.call('HSET', KEYS[2], 'owner', ARGV[1], 'hostname', ARGV[3], 'pid', ARGV[4], 'acquired_at', ARGV[5])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// reentrantAcquireScript acquires a reentrant lock stored as a hash with the owner and hold count.
+// The hold count is incremented when the lock is acquired again by the same owner.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+// Returns: 1 if the lock was acquired and 0 otherwise.
+var reentrantAcquireScript = redis.NewScript(2, `
+local owner = redis.call('HGET', KEYS[1], 'owner')
+if owner and owner ~= ARGV[1] then
+	return 0
+end
+if not owner then
+	redis.call('HSET', KEYS[1], 'owner', ARGV[1])
+	redis.call('DEL', KEYS[2])
+	redis.call('HSET', KEYS[2], 'owner', ARGV[1], 'hostname', ARGV[3], 'pid', ARGV[4], 'acquired_at', ARGV[5])
+end
+redis.call('HINCRBY', KEYS[1], 'count', 1)
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// fairAcquireScript acquires a lock granting it to waiters in the order of their arrival.
+// Waiters are kept in a queue sorted by arrival time and must refresh their entries
+// before the queue timeout, otherwise they are considered gone and removed.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+//   - KEYS[3]  a queue key with waiters sorted by arrival time
+//   - KEYS[4]  a queue timeouts key with waiters sorted by expiration time
+//   - ARGV[6]  a queue timeout in milliseconds (0 to not enqueue the owner)
+//   - ARGV[7]  1 for a reentrant lock and 0 otherwise
+// Returns: 1 if the lock was acquired and 0 otherwise.
+var fairAcquireScript = redis.NewScript(4, `
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local queueTimeout = tonumber(ARGV[6])
+local reentrant = ARGV[7] == '1'
+
+local expired = redis.call('ZRANGEBYSCORE', KEYS[4], '-inf', now)
+for _, waiter in ipairs(expired) do
+	redis.call('ZREM', KEYS[3], waiter)
+	redis.call('ZREM', KEYS[4], waiter)
+end
+
+if reentrant and redis.call('HGET', KEYS[1], 'owner') == ARGV[1] then
+	redis.call('HINCRBY', KEYS[1], 'count', 1)
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	redis.call('PEXPIRE', KEYS[2], ARGV[2])
+	return 1
+end
+
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	local head = redis.call('ZRANGE', KEYS[3], 0, 0)
+	if #head == 0 or head[1] == ARGV[1] then
+		if reentrant then
+			redis.call('HSET', KEYS[1], 'owner', ARGV[1], 'count', 1)
+			redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		else
+			redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+		end
+		redis.call('DEL', KEYS[2])
+		redis.call('HSET', KEYS[2], 'owner', ARGV[1], 'hostname', ARGV[3], 'pid', ARGV[4], 'acquired_at', ARGV[5])
+		redis.call('PEXPIRE', KEYS[2], ARGV[2])
+		redis.call('ZREM', KEYS[3], ARGV[1])
+		redis.call('ZREM', KEYS[4], ARGV[1])
+		return 1
+	end
+end
+
+if queueTimeout > 0 then
+	if not redis.call('ZSCORE', KEYS[3], ARGV[1]) then
+		redis.call('ZADD', KEYS[3], now, ARGV[1])
+	end
+	redis.call('ZADD', KEYS[4], now + queueTimeout, ARGV[1])
+	redis.call('PEXPIRE', KEYS[3], queueTimeout)
+	redis.call('PEXPIRE', KEYS[4], queueTimeout)
+end
+return 0
+`)
+
+// releaseScript removes a lock when it belongs to the owner and notifies waiters.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+//   - ARGV[1]  an owner id
+//   - ARGV[2]  a channel to publish the release notification
+// Returns: 1 if the lock was released and 0 otherwise.
+var releaseScript = redis.NewScript(2, `
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+redis.call('PUBLISH', ARGV[2], KEYS[1])
+return 1
+`)
+
+// reentrantReleaseScript decrements the hold count of a reentrant lock
+// and removes the lock when the count reaches zero. Waiters are notified when the lock is removed.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+//   - ARGV[1]  an owner id
+//   - ARGV[2]  a channel to publish the release notification
+// Returns: 1 if the lock was released, 0 if it is still held and -1 if it belongs to another owner.
+var reentrantReleaseScript = redis.NewScript(2, `
+if redis.call('HGET', KEYS[1], 'owner') ~= ARGV[1] then
+	return -1
+end
+if redis.call('HINCRBY', KEYS[1], 'count', -1) > 0 then
+	return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+redis.call('PUBLISH', ARGV[2], KEYS[1])
+return 1
+`)
+
+// lockInfoScript reads the owner, hold count, remaining timeout and owner metadata of a lock.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+// Returns: an array with owner id, hold count, timeout in milliseconds, host name, process id
+// and acquisition time or nil if the lock is not held.
+var lockInfoScript = redis.NewScript(2, `
+local kind = redis.call('TYPE', KEYS[1])['ok']
+local owner
+local count = 1
+if kind == 'string' then
+	owner = redis.call('GET', KEYS[1])
+elseif kind == 'hash' then
+	owner = redis.call('HGET', KEYS[1], 'owner')
+	count = tonumber(redis.call('HGET', KEYS[1], 'count')) or 0
+else
+	return false
+end
+local info = redis.call('HMGET', KEYS[2], 'hostname', 'pid', 'acquired_at')
+return {owner, count, redis.call('PTTL', KEYS[1]), info[1], info[2], info[3]}
+`)
+
+// extendScript prolongs a lock when it belongs to the owner.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+//   - ARGV[1]  an owner id
+//   - ARGV[2]  a new lock timeout in milliseconds
+// Returns: 1 if the lock was extended and 0 otherwise.
+var extendScript = redis.NewScript(2, `
+local kind = redis.call('TYPE', KEYS[1])['ok']
+local owner
+if kind == 'string' then
+	owner = redis.call('GET', KEYS[1])
+elseif kind == 'hash' then
+	owner = redis.call('HGET', KEYS[1], 'owner')
+end
+if owner ~= ARGV[1] then
+	return 0
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// forceReleaseScript removes a lock regardless of its owner and notifies waiters.
+//   - KEYS[1]  a lock key
+//   - KEYS[2]  a lock info key
+//   - ARGV[1]  a channel to publish the release notification
+// Returns: 1 if the lock was removed and 0 if it was not held.
+var forceReleaseScript = redis.NewScript(2, `
+redis.call('DEL', KEYS[2])
+if redis.call('DEL', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('PUBLISH', ARGV[1], KEYS[1])
+return 1
+`)
@@ -0,0 +1,312 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisReadWriteLock is a distributed read/write lock that is implemented based on Redis in-memory database.
+Many readers can hold the lock at the same time while a writer gets an exclusive access.
+A writer that waits for the lock blocks new readers, so writers are not starved by a stream of readers.
+Each read lock acquisition gets a unique token, so readers sharing the component release only their own locks.
+Write lock owners are identified by the component instance.
+
+Configuration parameters:
+
+  - options:
+    - retry_timeout:         timeout in milliseconds to retry lock acquisition. (Default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    lock := NewRedisReadWriteLock();
+    lock.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = lock.Open(ctx, "123")
+      ...
+
+    token, err := lock.AcquireReadLock(ctx, "123", "key1", 3000, 1000)
+    if err == nil {
+    	// Reading...
+    	err = lock.ReleaseReadLock(ctx, "123", "key1", token)
+    }
+
+    err = lock.AcquireWriteLock(ctx, "123", "key1", 3000, 1000)
+    if err == nil {
+    	// Writing...
+    	err = lock.ReleaseWriteLock(ctx, "123", "key1")
+    }
+*/
+type RedisReadWriteLock struct {
+	connection *rconnect.RedisConnection
+
+	lockId       string
+	retryTimeout int64
+
+	client redis.Conn
+}
+
+// NewRedisReadWriteLock method are creates a new instance of this lock.
+func NewRedisReadWriteLock() *RedisReadWriteLock {
+	return &RedisReadWriteLock{
+		connection:   rconnect.NewRedisConnection(),
+		lockId:       cdata.IdGenerator.NextLong(),
+		retryTimeout: 100,
+		client:       nil,
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisReadWriteLock) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+
+	c.retryTimeout = config.GetAsLongWithDefault("options.retry_timeout", c.retryTimeout)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisReadWriteLock) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisReadWriteLock) IsOpen() bool {
+	return c.client != nil
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisReadWriteLock) Open(ctx context.Context, correlationId string) error {
+	client, err := dialConnection(ctx, correlationId, c.connection)
+	if err != nil {
+		return err
+	}
+	c.client = client
+	return nil
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisReadWriteLock) Close(ctx context.Context, correlationId string) error {
+	if c.client != nil {
+		err := c.client.Close()
+		c.client = nil
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RedisReadWriteLock) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisReadWriteLock) writerKey(key string) string {
+	return key + ":writer"
+}
+
+func (c *RedisReadWriteLock) readersKey(key string) string {
+	return key + ":readers"
+}
+
+func (c *RedisReadWriteLock) waitingWriterKey(key string) string {
+	return key + ":writer_waiting"
+}
+
+// TryAcquireReadLock method are makes a single attempt to acquire a shared read lock by its key.
+// It returns immediately a reader token or an empty string when the lock is busy.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to acquire.
+//  - ttl               a lock timeout (time to live) in milliseconds.
+// Returns: a reader token to release the lock or error.
+func (c *RedisReadWriteLock) TryAcquireReadLock(ctx context.Context, correlationId string, key string, ttl int64) (token string, err error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return "", err
+	}
+
+	token = cdata.IdGenerator.NextLong()
+	res, err := redis.Int(readLockAcquireScript.Do(c.client,
+		c.writerKey(key), c.readersKey(key), c.waitingWriterKey(key), token, ttl))
+	if err != nil || res != 1 {
+		return "", err
+	}
+	return token, nil
+}
+
+// AcquireReadLock method are makes multiple attempts to acquire a shared read lock by its key within give time interval.
+// The acquisition stops as soon as the context is cancelled.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to acquire.
+//  - ttl               a lock timeout (time to live) in milliseconds.
+//  - timeout           a lock acquisition timeout in milliseconds.
+// Returns: a reader token to release the lock or error.
+func (c *RedisReadWriteLock) AcquireReadLock(ctx context.Context, correlationId string, key string, ttl int64, timeout int64) (token string, err error) {
+	expireTime := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+
+	for {
+		token, err = c.TryAcquireReadLock(ctx, correlationId, key, ttl)
+		if token != "" || err != nil {
+			return token, err
+		}
+
+		if err = c.waitRetry(ctx, correlationId, "read", key, expireTime); err != nil {
+			return "", err
+		}
+	}
+}
+
+// waitRetry waits for the next acquisition attempt.
+// It returns an error when the acquisition time is over or the context is cancelled.
+func (c *RedisReadWriteLock) waitRetry(ctx context.Context, correlationId string, kind string, key string, expireTime time.Time) error {
+	wait := time.Until(expireTime)
+	if wait <= 0 {
+		return cerr.NewConflictError(
+			correlationId,
+			"LOCK_TIMEOUT",
+			"Acquiring "+kind+" lock "+key+" failed on timeout",
+		).WithDetails("key", key)
+	}
+	if retryWait := time.Duration(c.retryTimeout) * time.Millisecond; wait > retryWait {
+		wait = retryWait
+	}
+
+	select {
+	case <-ctx.Done():
+		return cerr.NewInvalidStateError(
+			correlationId,
+			"LOCK_CANCELLED",
+			"Acquiring "+kind+" lock "+key+" was cancelled",
+		).WithDetails("key", key).WithCause(ctx.Err())
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// ReleaseReadLock method are releases prevously acquired read lock by its key.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to release.
+//  - token             a reader token returned on acquisition.
+// Returns: error or nil for success.
+func (c *RedisReadWriteLock) ReleaseReadLock(ctx context.Context, correlationId string, key string, token string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	_, err = c.client.Do("ZREM", c.readersKey(key), token)
+	return err
+}
+
+// TryAcquireWriteLock method are makes a single attempt to acquire an exclusive write lock by its key.
+// It returns immediately a positive or negative result.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to acquire.
+//  - ttl               a lock timeout (time to live) in milliseconds.
+// Returns: a lock result or error.
+func (c *RedisReadWriteLock) TryAcquireWriteLock(ctx context.Context, correlationId string, key string, ttl int64) (result bool, err error) {
+	return c.tryAcquireWriteLock(correlationId, key, ttl, 0)
+}
+
+func (c *RedisReadWriteLock) tryAcquireWriteLock(correlationId string, key string, ttl int64, waitTimeout int64) (result bool, err error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return false, err
+	}
+
+	res, err := redis.Int(writeLockAcquireScript.Do(c.client,
+		c.writerKey(key), c.readersKey(key), c.waitingWriterKey(key), c.lockId, ttl, waitTimeout))
+	return res == 1, err
+}
+
+// AcquireWriteLock method are makes multiple attempts to acquire an exclusive write lock by its key within give time interval.
+// While waiting the writer prevents new readers from acquiring the lock.
+// The acquisition stops as soon as the context is cancelled.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to acquire.
+//  - ttl               a lock timeout (time to live) in milliseconds.
+//  - timeout           a lock acquisition timeout in milliseconds.
+// Returns: error or nil for success.
+func (c *RedisReadWriteLock) AcquireWriteLock(ctx context.Context, correlationId string, key string, ttl int64, timeout int64) error {
+	expireTime := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+
+	// The waiting mark outlives a few retries, so it disappears soon when the writer is gone
+	waitTimeout := 3 * c.retryTimeout
+
+	for {
+		locked, err := c.tryAcquireWriteLock(correlationId, key, ttl, waitTimeout)
+		if locked || err != nil {
+			return err
+		}
+
+		if err = c.waitRetry(ctx, correlationId, "write", key, expireTime); err != nil {
+			// Let the readers proceed
+			if _, delErr := compareAndDeleteScript.Do(c.client, c.waitingWriterKey(key), c.lockId); delErr != nil {
+				return delErr
+			}
+			return err
+		}
+	}
+}
+
+// ReleaseWriteLock method are releases prevously acquired write lock by its key.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique lock key to release.
+// Returns: error or nil for success.
+func (c *RedisReadWriteLock) ReleaseWriteLock(ctx context.Context, correlationId string, key string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	_, err = compareAndDeleteScript.Do(c.client, c.writerKey(key), c.lockId)
+	return err
+}
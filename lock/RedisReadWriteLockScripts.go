@@ -0,0 +1,69 @@
+package lock
+
+import "github.com/gomodule/redigo/redis"
+
+// Keys used by the read/write lock scripts:
+//   - KEYS[1]  a writer key that holds the token of the current writer
+//   - KEYS[2]  a readers key that holds a sorted set of reader tokens scored by their expiration time
+//   - KEYS[3]  a waiting writer key that blocks new readers while a writer is waiting for the lock
+
+// readLockAcquireScript acquires a shared read lock.
+// New readers are rejected while a writer holds the lock or waits for it.
+//   - ARGV[1]  a reader token
+//   - ARGV[2]  a lock timeout in milliseconds
+// Returns: 1 if the lock was acquired and 0 otherwise.
+var readLockAcquireScript = redis.NewScript(3, `
+redis.replicate_commands()
+if redis.call('EXISTS', KEYS[1]) == 1 or redis.call('EXISTS', KEYS[3]) == 1 then
+	return 0
+end
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local ttl = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', now)
+redis.call('ZADD', KEYS[2], now + ttl, ARGV[1])
+if redis.call('PTTL', KEYS[2]) < ttl then
+	redis.call('PEXPIRE', KEYS[2], ttl)
+end
+return 1
+`)
+
+// writeLockAcquireScript acquires an exclusive write lock.
+// When the lock is busy the writer may register itself as waiting to prevent new readers
+// from acquiring the lock, so the writer does not starve.
+//   - ARGV[1]  a writer token
+//   - ARGV[2]  a lock timeout in milliseconds
+//   - ARGV[3]  a timeout of the waiting writer mark in milliseconds (0 to not wait)
+// Returns: 1 if the lock was acquired and 0 otherwise.
+var writeLockAcquireScript = redis.NewScript(3, `
+redis.replicate_commands()
+local waiting = redis.call('GET', KEYS[3])
+if waiting and waiting ~= ARGV[1] then
+	return 0
+end
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', now)
+if redis.call('EXISTS', KEYS[1]) == 1 or redis.call('ZCARD', KEYS[2]) > 0 then
+	if tonumber(ARGV[3]) > 0 then
+		redis.call('SET', KEYS[3], ARGV[1], 'PX', ARGV[3])
+	end
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+if waiting then
+	redis.call('DEL', KEYS[3])
+end
+return 1
+`)
+
+// compareAndDeleteScript removes a key only when it holds the expected token.
+//   - KEYS[1]  a key to remove
+//   - ARGV[1]  an expected token
+// Returns: 1 if the key was removed and 0 otherwise.
+var compareAndDeleteScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
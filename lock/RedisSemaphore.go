@@ -0,0 +1,226 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisSemaphore is a distributed counting semaphore that is implemented based on Redis in-memory database.
+It limits the number of holders that can acquire a semaphore by the same key at the same time.
+Each holder gets a unique token that expires after the given timeout, so permits of crashed holders are returned back.
+
+Configuration parameters:
+
+  - options:
+    - permits:               maximum number of concurrent holders (default: 1)
+    - retry_timeout:         timeout in milliseconds to retry permit acquisition. (Default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    semaphore := NewRedisSemaphore();
+    semaphore.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.permits", 5,
+    ));
+
+    err = semaphore.Open(ctx, "123")
+      ...
+
+    token, err := semaphore.Acquire(ctx, "123", "key1", 3000, 1000)
+    if err == nil {
+    	// Processing...
+    	err = semaphore.Release(ctx, "123", "key1", token)
+    }
+*/
+type RedisSemaphore struct {
+	connection *rconnect.RedisConnection
+
+	permits      int
+	retryTimeout int64
+
+	client redis.Conn
+}
+
+// NewRedisSemaphore method are creates a new instance of this semaphore.
+func NewRedisSemaphore() *RedisSemaphore {
+	return &RedisSemaphore{
+		connection:   rconnect.NewRedisConnection(),
+		permits:      1,
+		retryTimeout: 100,
+		client:       nil,
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisSemaphore) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+
+	c.permits = config.GetAsIntegerWithDefault("options.permits", c.permits)
+	if c.permits < 1 {
+		c.permits = 1
+	}
+	c.retryTimeout = config.GetAsLongWithDefault("options.retry_timeout", c.retryTimeout)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisSemaphore) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisSemaphore) IsOpen() bool {
+	return c.client != nil
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisSemaphore) Open(ctx context.Context, correlationId string) error {
+	client, err := dialConnection(ctx, correlationId, c.connection)
+	if err != nil {
+		return err
+	}
+	c.client = client
+	return nil
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisSemaphore) Close(ctx context.Context, correlationId string) error {
+	if c.client != nil {
+		err := c.client.Close()
+		c.client = nil
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RedisSemaphore) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// TryAcquire method are makes a single attempt to acquire a permit by the semaphore key.
+// It returns immediately a holder token or an empty string when all permits are taken.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique semaphore key.
+//  - ttl               a permit timeout (time to live) in milliseconds.
+// Returns: a holder token to release the permit or error.
+func (c *RedisSemaphore) TryAcquire(ctx context.Context, correlationId string, key string, ttl int64) (token string, err error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return "", err
+	}
+
+	token = cdata.IdGenerator.NextLong()
+	res, err := redis.Int(semaphoreAcquireScript.Do(c.client, key, token, ttl, c.permits))
+	if err != nil || res != 1 {
+		return "", err
+	}
+	return token, nil
+}
+
+// Acquire method are makes multiple attempts to acquire a permit by the semaphore key within give time interval.
+// The first attempt is made even when the timeout is over. Waiting stops when the context is cancelled.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique semaphore key.
+//  - ttl               a permit timeout (time to live) in milliseconds.
+//  - timeout           a permit acquisition timeout in milliseconds.
+// Returns: a holder token to release the permit or error.
+func (c *RedisSemaphore) Acquire(ctx context.Context, correlationId string, key string, ttl int64, timeout int64) (token string, err error) {
+	expireTime := time.Now().Add(time.Duration(timeout) * time.Millisecond)
+
+	for {
+		token, err = c.TryAcquire(ctx, correlationId, key, ttl)
+		if token != "" || err != nil {
+			return token, err
+		}
+
+		if err = c.waitRetry(ctx, correlationId, key, expireTime); err != nil {
+			return "", err
+		}
+	}
+}
+
+// waitRetry waits for the next acquisition attempt.
+// It returns an error when the acquisition time is over or the context is cancelled.
+func (c *RedisSemaphore) waitRetry(ctx context.Context, correlationId string, key string, expireTime time.Time) error {
+	wait := time.Until(expireTime)
+	if wait <= 0 {
+		return cerr.NewConflictError(
+			correlationId,
+			"SEMAPHORE_TIMEOUT",
+			"Acquiring semaphore "+key+" failed on timeout",
+		).WithDetails("key", key)
+	}
+	if retryWait := time.Duration(c.retryTimeout) * time.Millisecond; wait > retryWait {
+		wait = retryWait
+	}
+
+	select {
+	case <-ctx.Done():
+		return cerr.NewInvalidStateError(
+			correlationId,
+			"SEMAPHORE_CANCELLED",
+			"Acquiring semaphore "+key+" was cancelled",
+		).WithDetails("key", key).WithCause(ctx.Err())
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// Release method are returns a permit previously acquired by the holder.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - key               a unique semaphore key.
+//  - token             a holder token returned on acquisition.
+// Returns: error or nil for success.
+func (c *RedisSemaphore) Release(ctx context.Context, correlationId string, key string, token string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	_, err = c.client.Do("ZREM", key, token)
+	return err
+}
@@ -0,0 +1,26 @@
+package lock
+
+import "github.com/gomodule/redigo/redis"
+
+// semaphoreAcquireScript acquires a permit from a semaphore stored as a sorted set
+// of holder tokens scored by their expiration time. Expired holders are removed first.
+//   - KEYS[1]  a semaphore key
+//   - ARGV[1]  a holder token
+//   - ARGV[2]  a permit timeout in milliseconds
+//   - ARGV[3]  a maximum number of permits
+// Returns: 1 if the permit was acquired and 0 otherwise.
+var semaphoreAcquireScript = redis.NewScript(1, `
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local ttl = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], now + ttl, ARGV[1])
+if redis.call('PTTL', KEYS[1]) < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
@@ -0,0 +1,262 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+const (
+	// Writes log messages into a Redis Stream with a field per message property.
+	StreamMode = "stream"
+	// Writes log messages into a Redis list as JSON objects.
+	ListMode = "list"
+)
+
+/*
+RedisLogger is a logger that writes log messages into Redis in-memory database.
+Messages are cached in memory and periodically written in batches into a capped stream or list.
+The oldest messages are trimmed when the maximum length is reached.
+
+Configuration parameters:
+
+  - level:                   maximum log level to capture
+  - source:                  source (context) name
+  - options:
+    - key:                   name of the stream or list (default: "logs")
+    - mode:                  storage mode: "stream" or "list" (default: "stream")
+    - max_length:            maximum number of kept messages (default: 10000)
+    - interval:              interval in milliseconds to save log messages (default: 10 seconds)
+    - max_cache_size:        maximum number of messages stored in this cache (default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:context-info:*:*:1.0     (optional) ContextInfo to detect the context id and specify counters source
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    logger := NewRedisLogger();
+    logger.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.max_length", 1000,
+    ));
+
+    err = logger.Open(ctx, "123")
+      ...
+
+    logger.Error(ctx, "123", ex, "Error occured: %s", ex.Error())
+    logger.Debug(ctx, "123", "Everything is OK.")
+*/
+type RedisLogger struct {
+	*clog.CachedLogger
+	connection *rconnect.RedisConnection
+
+	key       string
+	mode      string
+	maxLength int64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mtx    sync.Mutex
+}
+
+// NewRedisLogger method are creates a new instance of the logger.
+func NewRedisLogger() *RedisLogger {
+	c := &RedisLogger{
+		connection: rconnect.NewRedisConnection(),
+		key:        "logs",
+		mode:       StreamMode,
+		maxLength:  10000,
+	}
+	c.CachedLogger = clog.InheritCachedLogger(c)
+	return c
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisLogger) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.CachedLogger.Configure(ctx, config)
+	c.connection.Configure(ctx, config)
+
+	c.key = config.GetAsStringWithDefault("options.key", c.key)
+	c.mode = config.GetAsStringWithDefault("options.mode", c.mode)
+	c.maxLength = config.GetAsLongWithDefault("options.max_length", c.maxLength)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisLogger) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.CachedLogger.SetReferences(ctx, references)
+	c.connection.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisLogger) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component and starts saving cached messages periodically.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisLogger) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.done != nil {
+		return nil
+	}
+
+	if c.mode != StreamMode && c.mode != ListMode {
+		return cerr.NewConfigError(correlationId, "WRONG_MODE", "Logger mode "+c.mode+" is not supported").
+			WithDetails("mode", c.mode)
+	}
+
+	err := c.connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	dumpCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.dumpPeriodically(dumpCtx, c.done)
+
+	return nil
+}
+
+// Close method are saves cached messages, closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisLogger) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel = nil
+	c.done = nil
+	c.mtx.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+
+	err := c.Dump(ctx)
+	closeErr := c.connection.Close(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func (c *RedisLogger) dumpPeriodically(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	interval := time.Duration(c.Interval) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Duration(clog.DefaultInterval) * time.Millisecond
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Failed messages are kept in the cache and saved next time
+			_ = c.Dump(ctx)
+		}
+	}
+}
+
+// Save method are writes a batch of log messages into the stream or list.
+// It is called when cached messages are dumped and shall not be called directly.
+// Parameters:
+//   - ctx context.Context
+//   - messages      a list of log messages to be saved.
+// Returns: error or nil for success.
+func (c *RedisLogger) Save(ctx context.Context, messages []clog.LogMessage) error {
+	if !c.IsOpen() {
+		return cerr.NewInvalidStateError("", "NOT_OPENED", "Connection is not opened")
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	var pipe redis.Pipeliner
+	if c.mode == ListMode {
+		pipe = c.connection.GetClient().TxPipeline()
+		values := make([]any, 0, len(messages))
+		for _, message := range messages {
+			data, err := json.Marshal(message)
+			if err != nil {
+				return err
+			}
+			values = append(values, data)
+		}
+		pipe.RPush(c.key, values...)
+		if c.maxLength > 0 {
+			pipe.LTrim(c.key, -c.maxLength, -1)
+		}
+	} else {
+		pipe = c.connection.GetClient().Pipeline()
+		for _, message := range messages {
+			values, err := c.toStreamValues(message)
+			if err != nil {
+				return err
+			}
+			pipe.XAdd(&redis.XAddArgs{
+				Stream:       c.key,
+				MaxLenApprox: c.maxLength,
+				Values:       values,
+			})
+		}
+	}
+
+	_, err := pipe.Exec()
+	return err
+}
+
+func (c *RedisLogger) toStreamValues(message clog.LogMessage) (map[string]any, error) {
+	values := map[string]any{
+		"time":           cconv.StringConverter.ToString(message.Time),
+		"source":         message.Source,
+		"level":          clog.LevelConverter.ToString(message.Level),
+		"correlation_id": message.CorrelationId,
+		"message":        message.Message,
+	}
+
+	if message.Error.Code != "" || message.Error.Message != "" {
+		data, err := json.Marshal(message.Error)
+		if err != nil {
+			return nil, err
+		}
+		values["error"] = data
+	}
+	return values, nil
+}
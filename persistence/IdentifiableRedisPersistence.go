@@ -0,0 +1,469 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-redis/redis"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+/*
+IdentifiableRedisPersistence is an abstract persistence component that stores data items with unique ids
+in Redis in-memory database and implements a number of CRUD operations over data items.
+
+The data items must have an id taken from the GetId method of the IIdentifiable interface
+or from the Id field. If an id is not set when the item is created and the id is a string,
+a new unique id is generated.
+
+Writes are executed in transactions guarded by WATCH, so concurrent changes of the same item
+are retried and never mixed.
+
+Configuration parameters:
+
+  - collection:              (optional) a name of the collection used as a prefix of keys
+  - indexes:                 indexed fields with their types: "string" or "number", e.g. "indexes.name=string"
+  - options:
+    - json_module:           stores items with the RedisJSON module (default: false)
+    - max_page_size:         maximum number of items returned in a single page (default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	type MyData struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	type MyRedisPersistence struct {
+		*IdentifiableRedisPersistence[MyData, string]
+	}
+
+	func NewMyRedisPersistence() *MyRedisPersistence {
+		return &MyRedisPersistence{
+			IdentifiableRedisPersistence: NewIdentifiableRedisPersistence[MyData, string]("mydata"),
+		}
+	}
+
+	persistence := NewMyRedisPersistence()
+	persistence.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"host", "localhost",
+		"port", 6379,
+	))
+
+	err := persistence.Open(ctx, "123")
+	...
+
+	item, err := persistence.Create(ctx, "123", MyData{Id: "1", Name: "ABC"})
+	item, err = persistence.GetOneById(ctx, "123", "1")
+	fmt.Println(item.Name)                 // Result: "ABC"
+
+	item, err = persistence.DeleteById(ctx, "123", "1")
+*/
+type IdentifiableRedisPersistence[T any, K any] struct {
+	*RedisPersistence[T]
+}
+
+// NewIdentifiableRedisPersistence method are creates a new instance of the persistence component.
+// Parameters:
+//   - collection    (optional) a collection name.
+func NewIdentifiableRedisPersistence[T any, K any](collection string) *IdentifiableRedisPersistence[T, K] {
+	return &IdentifiableRedisPersistence[T, K]{
+		RedisPersistence: NewRedisPersistence[T](collection),
+	}
+}
+
+// GetOneById method are gets a data item by its unique id.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - id                an id of data item to be retrieved.
+// Returns: a found data item, the zero value if nothing was found, or error.
+func (c *IdentifiableRedisPersistence[T, K]) GetOneById(ctx context.Context, correlationId string, id K) (T, error) {
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	key := c.idToString(id)
+	data, err := c.readData(c.Connection.GetClient(), key)
+	if err != nil || data == "" {
+		if err == nil {
+			c.Logger.Trace(ctx, correlationId, "Nothing found from %s with id = %s", c.CollectionName, key)
+		}
+		return result, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Retrieved from %s with id = %s", c.CollectionName, key)
+	return c.decode(data)
+}
+
+// GetListByIds method are gets a list of data items by their unique ids.
+// Missing items are skipped.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - ids               ids of data items to be retrieved.
+// Returns: a list with found data items or error.
+func (c *IdentifiableRedisPersistence[T, K]) GetListByIds(ctx context.Context, correlationId string, ids []K) ([]T, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = c.idToString(id)
+	}
+
+	items, err := c.readItems(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Retrieved %d from %s", len(items), c.CollectionName)
+	return items, nil
+}
+
+// Create method are creates a data item.
+// If the item has no id and the id is a string, a new unique id is generated.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - item              an item to be created.
+// Returns: a created item or error. ConflictError is returned if an item with the same id already exists.
+func (c *IdentifiableRedisPersistence[T, K]) Create(ctx context.Context, correlationId string, item T) (T, error) {
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	item = c.generateObjectId(item)
+	id := c.idToString(c.getObjectId(item))
+	data, err := c.encode(item)
+	if err != nil {
+		return result, err
+	}
+
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldData, err := c.readData(tx, id)
+		if err != nil {
+			return err
+		}
+		if oldData != "" {
+			return cerr.NewConflictError(correlationId, "ALREADY_EXISTS",
+				"Item with id "+id+" already exists in "+c.CollectionName).
+				WithDetails("id", id)
+		}
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			return c.saveData(pipe, id, oldData, data)
+		})
+		return err
+	}, c.getItemKey(id))
+	if err != nil {
+		return result, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Created in %s with id = %s", c.CollectionName, id)
+	return item, nil
+}
+
+// Set method are sets a data item. If the data item exists it updates it,
+// otherwise it creates a new data item.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - item              an item to be set.
+// Returns: an updated item or error.
+func (c *IdentifiableRedisPersistence[T, K]) Set(ctx context.Context, correlationId string, item T) (T, error) {
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	item = c.generateObjectId(item)
+	id := c.idToString(c.getObjectId(item))
+	data, err := c.encode(item)
+	if err != nil {
+		return result, err
+	}
+
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldData, err := c.readData(tx, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			return c.saveData(pipe, id, oldData, data)
+		})
+		return err
+	}, c.getItemKey(id))
+	if err != nil {
+		return result, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Set in %s with id = %s", c.CollectionName, id)
+	return item, nil
+}
+
+// Update method are updates a data item.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - item              an item to be updated.
+// Returns: an updated item, the zero value if the item was not found, or error.
+func (c *IdentifiableRedisPersistence[T, K]) Update(ctx context.Context, correlationId string, item T) (T, error) {
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	id := c.idToString(c.getObjectId(item))
+	data, err := c.encode(item)
+	if err != nil {
+		return result, err
+	}
+
+	found := false
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldData, err := c.readData(tx, id)
+		if err != nil || oldData == "" {
+			found = false
+			return err
+		}
+		found = true
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			return c.saveData(pipe, id, oldData, data)
+		})
+		return err
+	}, c.getItemKey(id))
+	if err != nil || !found {
+		return result, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Updated in %s with id = %s", c.CollectionName, id)
+	return item, nil
+}
+
+// UpdatePartially method are updates only few selected fields in a data item.
+// The fields are named as they appear in the JSON representation of the item.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - id                an id of data item to be updated.
+//   - data              a map with fields to be updated.
+// Returns: an updated item, the zero value if the item was not found, or error.
+func (c *IdentifiableRedisPersistence[T, K]) UpdatePartially(ctx context.Context, correlationId string,
+	id K, data cdata.AnyValueMap) (T, error) {
+
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	key := c.idToString(id)
+	found := false
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldData, err := c.readData(tx, key)
+		if err != nil || oldData == "" {
+			found = false
+			return err
+		}
+		found = true
+
+		var values map[string]any
+		if err = json.Unmarshal([]byte(oldData), &values); err != nil {
+			return err
+		}
+		for field, value := range data.Value() {
+			values[field] = value
+		}
+
+		newData, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		// Round trip through the item type drops unknown fields
+		if result, err = c.decode(string(newData)); err != nil {
+			return err
+		}
+		encoded, err := c.encode(result)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			return c.saveData(pipe, key, oldData, encoded)
+		})
+		return err
+	}, c.getItemKey(key))
+	if err != nil || !found {
+		var empty T
+		return empty, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Partially updated in %s with id = %s", c.CollectionName, key)
+	return result, nil
+}
+
+// DeleteById method are deleted a data item by it's unique id.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - id                an id of the item to be deleted
+// Returns: a deleted item, the zero value if the item was not found, or error.
+func (c *IdentifiableRedisPersistence[T, K]) DeleteById(ctx context.Context, correlationId string, id K) (T, error) {
+	var result T
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return result, err
+	}
+
+	key := c.idToString(id)
+	var oldData string
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldData, err = c.readData(tx, key)
+		if err != nil || oldData == "" {
+			return err
+		}
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			return c.deleteData(pipe, key, oldData)
+		})
+		return err
+	}, c.getItemKey(key))
+	if err != nil || oldData == "" {
+		return result, err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Deleted from %s with id = %s", c.CollectionName, key)
+	return c.decode(oldData)
+}
+
+// DeleteByIds method are deletes multiple data items by their unique ids in a single transaction.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - ids               ids of data items to be deleted.
+// Returns: error or nil for success.
+func (c *IdentifiableRedisPersistence[T, K]) DeleteByIds(ctx context.Context, correlationId string, ids []K) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	itemKeys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = c.idToString(id)
+		itemKeys[i] = c.getItemKey(keys[i])
+	}
+
+	deleted := 0
+	err = c.transaction(correlationId, func(tx *redis.Tx) error {
+		oldItems := make(map[string]string, len(keys))
+		for _, key := range keys {
+			oldData, err := c.readData(tx, key)
+			if err != nil {
+				return err
+			}
+			if oldData != "" {
+				oldItems[key] = oldData
+			}
+		}
+		deleted = len(oldItems)
+		if deleted == 0 {
+			return nil
+		}
+
+		_, err := tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			for key, oldData := range oldItems {
+				if err := c.deleteData(pipe, key, oldData); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		return err
+	}, itemKeys...)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Deleted %d items from %s", deleted, c.CollectionName)
+	return nil
+}
+
+func (c *IdentifiableRedisPersistence[T, K]) idToString(id K) string {
+	return cconv.StringConverter.ToString(id)
+}
+
+// getObjectId gets an id from the GetId method or the Id field of the item.
+func (c *IdentifiableRedisPersistence[T, K]) getObjectId(item T) K {
+	if identifiable, ok := any(item).(cdata.IIdentifiable[K]); ok {
+		return identifiable.GetId()
+	}
+
+	var id K
+	field := c.getIdField(reflect.ValueOf(&item))
+	if field.IsValid() {
+		if value, ok := field.Interface().(K); ok {
+			id = value
+		}
+	}
+	return id
+}
+
+// generateObjectId sets a new unique id into the Id field of the item when the id is not set.
+// Only string ids are generated.
+func (c *IdentifiableRedisPersistence[T, K]) generateObjectId(item T) T {
+	id := c.getObjectId(item)
+	if !reflect.ValueOf(&id).Elem().IsZero() {
+		return item
+	}
+
+	field := c.getIdField(reflect.ValueOf(&item))
+	if field.IsValid() && field.CanSet() && field.Kind() == reflect.String {
+		field.SetString(cdata.IdGenerator.NextLong())
+	}
+	return item
+}
+
+func (c *IdentifiableRedisPersistence[T, K]) getIdField(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return value.FieldByName("Id")
+}
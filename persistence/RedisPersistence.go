@@ -0,0 +1,416 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+const (
+	// A number of attempts to repeat a transaction when watched keys are changed concurrently.
+	maxTransactionRetries = 10
+	// A number of items read from Redis in a single batch.
+	batchSize = 100
+)
+
+// processor is implemented by clients, transactions and pipelines to execute arbitrary commands.
+type processor interface {
+	Process(cmd redis.Cmder) error
+}
+
+/*
+RedisPersistence is an abstract persistence component that stores data items in Redis in-memory database as JSON.
+Items are kept in string keys or, when the RedisJSON module is enabled, in JSON keys.
+Keys of all items are tracked in a set to list the collection.
+
+Configured fields are indexed in sorted sets that are updated in the same transactions as items
+and used to filter, sort and page items in GetPageByFilter. When items are stored with the RedisJSON module
+and the RediSearch module is present, the fields are indexed by RediSearch and queried with FT.AGGREGATE.
+String values are compared case-sensitively and items with equal values are ordered by ids in both cases.
+
+All keys of the collection share the same hash tag, so the collection is kept in a single
+cluster slot and its items are updated in transactions.
+
+Configuration parameters:
+
+  - collection:              (optional) a name of the collection used as a prefix of keys
+  - indexes:                 indexed fields with their types: "string" or "number", e.g. "indexes.name=string"
+  - options:
+    - json_module:           stores items with the RedisJSON module (default: false)
+    - max_page_size:         maximum number of items returned in a single page (default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	type MyRedisPersistence struct {
+		*RedisPersistence[MyData]
+	}
+
+	func NewMyRedisPersistence() *MyRedisPersistence {
+		c := &MyRedisPersistence{
+			RedisPersistence: NewRedisPersistence[MyData]("mydata"),
+		}
+		c.EnsureIndex("name", StringIndex)
+		return c
+	}
+
+	func (c *MyRedisPersistence) GetListByName(ctx context.Context, correlationId string, name string) ([]MyData, error) {
+		return c.GetListByFilter(ctx, correlationId, func(item MyData) bool {
+			return item.Name == name
+		}, nil)
+	}
+
+	func (c *MyRedisPersistence) GetPageByName(ctx context.Context, correlationId string, name string,
+		paging *cdata.PagingParams) (cdata.DataPage[MyData], error) {
+		filter := cdata.NewFilterParamsFromTuples("name", name)
+		sort := cdata.NewSortParams([]cdata.SortField{cdata.NewSortField("name", true)})
+		return c.GetPageByFilter(ctx, correlationId, filter, paging, sort)
+	}
+*/
+type RedisPersistence[T any] struct {
+	// The Redis connection component.
+	Connection *rconnect.RedisConnection
+	// The logger.
+	Logger *clog.CompositeLogger
+	// The name of the collection.
+	CollectionName string
+	// The maximum number of items returned in a single page.
+	MaxPageSize int64
+
+	jsonModule   bool
+	searchModule bool
+	indexes      []*indexDefinition
+}
+
+// NewRedisPersistence method are creates a new instance of the persistence component.
+// Parameters:
+//   - collection    (optional) a collection name.
+func NewRedisPersistence[T any](collection string) *RedisPersistence[T] {
+	return &RedisPersistence[T]{
+		Connection:     rconnect.NewRedisConnection(),
+		Logger:         clog.NewCompositeLogger(),
+		CollectionName: collection,
+		MaxPageSize:    100,
+		indexes:        make([]*indexDefinition, 0),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisPersistence[T]) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.Connection.Configure(ctx, config)
+	c.Logger.Configure(ctx, config)
+
+	c.CollectionName = config.GetAsStringWithDefault("collection", c.CollectionName)
+	c.jsonModule = config.GetAsBooleanWithDefault("options.json_module", c.jsonModule)
+	c.MaxPageSize = config.GetAsLongWithDefault("options.max_page_size", c.MaxPageSize)
+
+	indexes := config.GetSection("indexes")
+	for _, field := range indexes.Keys() {
+		c.EnsureIndex(field, IndexType(indexes.GetAsString(field)))
+	}
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisPersistence[T]) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.Connection.SetReferences(ctx, references)
+	c.Logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisPersistence[T]) IsOpen() bool {
+	return c.Connection.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisPersistence[T]) Open(ctx context.Context, correlationId string) error {
+	if c.CollectionName == "" {
+		return cerr.NewConfigError(correlationId, "NO_COLLECTION", "Collection name is not defined")
+	}
+
+	err := c.Connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	c.searchModule = false
+	if c.jsonModule && len(c.indexes) > 0 && c.hasSearchModule() {
+		err = c.createSearchIndex(correlationId)
+		if err != nil {
+			c.Connection.Close(ctx, correlationId)
+			return err
+		}
+		c.searchModule = true
+	}
+
+	c.Logger.Debug(ctx, correlationId, "Opened Redis persistence for collection %s", c.CollectionName)
+	return nil
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisPersistence[T]) Close(ctx context.Context, correlationId string) error {
+	return c.Connection.Close(ctx, correlationId)
+}
+
+func (c *RedisPersistence[T]) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Clear method are removes all items from the collection.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil for success.
+func (c *RedisPersistence[T]) Clear(ctx context.Context, correlationId string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	ids, err := c.Connection.GetClient().SMembers(c.getIdsKey()).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{c.getIdsKey()}
+	for _, index := range c.indexes {
+		keys = append(keys, c.getIndexKey(index.Field))
+	}
+	for _, id := range ids {
+		keys = append(keys, c.getItemKey(id))
+	}
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err = c.Connection.GetClient().Del(keys[start:end]...).Err(); err != nil {
+			return err
+		}
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Cleared collection %s", c.CollectionName)
+	return nil
+}
+
+// GetListByFilter method are gets a list of data items filtered and sorted in memory.
+// All items of the collection are read, so the method is suitable only for small collections.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - filter            (optional) a filter function to select items.
+//   - sortFunc          (optional) a function that returns true when the first item goes before the second.
+// Returns: a list of data items or error.
+func (c *RedisPersistence[T]) GetListByFilter(ctx context.Context, correlationId string,
+	filter func(item T) bool, sortFunc func(a, b T) bool) ([]T, error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	ids, err := c.Connection.GetClient().SMembers(c.getIdsKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.readItems(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if filter == nil || filter(item) {
+			result = append(result, item)
+		}
+	}
+	if sortFunc != nil {
+		sort.SliceStable(result, func(i, j int) bool { return sortFunc(result[i], result[j]) })
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Retrieved %d from %s", len(result), c.CollectionName)
+	return result, nil
+}
+
+// GetCountByFilter method are gets a number of data items that match the filter.
+// Without filter the number is read from the set of item ids.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - filter            (optional) a filter function to select items.
+// Returns: a number of data items or error.
+func (c *RedisPersistence[T]) GetCountByFilter(ctx context.Context, correlationId string,
+	filter func(item T) bool) (int64, error) {
+
+	if filter == nil {
+		state, err := c.checkOpened(correlationId)
+		if !state {
+			return 0, err
+		}
+		return c.Connection.GetClient().SCard(c.getIdsKey()).Result()
+	}
+
+	items, err := c.GetListByFilter(ctx, correlationId, filter, nil)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(items)), nil
+}
+
+// getKeyPrefix gets a common prefix of the collection keys with the hash tag.
+func (c *RedisPersistence[T]) getKeyPrefix() string {
+	return "{" + c.CollectionName + "}:"
+}
+
+func (c *RedisPersistence[T]) getItemKey(id string) string {
+	return c.getKeyPrefix() + "item:" + id
+}
+
+func (c *RedisPersistence[T]) getIdsKey() string {
+	return c.getKeyPrefix() + "ids"
+}
+
+func (c *RedisPersistence[T]) encode(item T) (string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *RedisPersistence[T]) decode(data string) (T, error) {
+	var item T
+	err := json.Unmarshal([]byte(data), &item)
+	return item, err
+}
+
+// readData reads a serialized item by its id. It returns an empty string when the item does not exist.
+func (c *RedisPersistence[T]) readData(p processor, id string) (string, error) {
+	var cmd *redis.StringCmd
+	if c.jsonModule {
+		cmd = redis.NewStringCmd("JSON.GET", c.getItemKey(id), ".")
+	} else {
+		cmd = redis.NewStringCmd("GET", c.getItemKey(id))
+	}
+	p.Process(cmd)
+
+	data, err := cmd.Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return data, err
+}
+
+// readItems reads items by their ids in batches and skips missing items.
+func (c *RedisPersistence[T]) readItems(ids []string) ([]T, error) {
+	items := make([]T, 0, len(ids))
+
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		args := make([]any, 0, end-start+2)
+		if c.jsonModule {
+			args = append(args, "JSON.MGET")
+		} else {
+			args = append(args, "MGET")
+		}
+		for _, id := range ids[start:end] {
+			args = append(args, c.getItemKey(id))
+		}
+		if c.jsonModule {
+			args = append(args, ".")
+		}
+
+		cmd := redis.NewSliceCmd(args...)
+		c.Connection.GetClient().Process(cmd)
+		values, err := cmd.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue
+			}
+			item, err := c.decode(data)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// saveData adds commands to write a serialized item and update its indexes into the pipeline.
+// The previous serialized item is empty when the item is created.
+func (c *RedisPersistence[T]) saveData(pipe redis.Pipeliner, id string, oldData string, data string) error {
+	if c.jsonModule {
+		pipe.Process(redis.NewStatusCmd("JSON.SET", c.getItemKey(id), ".", data))
+	} else {
+		pipe.Set(c.getItemKey(id), data, 0)
+	}
+	if oldData == "" {
+		pipe.SAdd(c.getIdsKey(), id)
+	}
+	return c.updateIndexes(pipe, id, oldData, data)
+}
+
+// deleteData adds commands to remove a serialized item and its index entries into the pipeline.
+func (c *RedisPersistence[T]) deleteData(pipe redis.Pipeliner, id string, oldData string) error {
+	pipe.Del(c.getItemKey(id))
+	pipe.SRem(c.getIdsKey(), id)
+	return c.updateIndexes(pipe, id, oldData, "")
+}
+
+// transaction runs the function in a transaction that fails when the watched keys are changed concurrently.
+// The transaction is repeated on such failures.
+func (c *RedisPersistence[T]) transaction(correlationId string, fn func(tx *redis.Tx) error, keys ...string) error {
+	for i := 0; i < maxTransactionRetries; i++ {
+		err := c.Connection.GetClient().Watch(fn, keys...)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+
+	return cerr.NewConflictError(correlationId, "TRANSACTION_FAILED",
+		"Transaction in "+c.CollectionName+" failed because of concurrent changes")
+}
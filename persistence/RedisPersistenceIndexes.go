@@ -0,0 +1,545 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// IndexType defines how values of an indexed field are compared.
+type IndexType string
+
+const (
+	// StringIndex compares values of the field as strings.
+	StringIndex IndexType = "string"
+	// NumberIndex compares values of the field as numbers.
+	NumberIndex IndexType = "number"
+)
+
+// A separator of values and ids in members of string indexes.
+const indexSeparator = "\x00"
+
+type indexDefinition struct {
+	Field string
+	Type  IndexType
+}
+
+// EnsureIndex method are adds an index on a field of data items.
+// The field is named as it appears in the JSON representation of items, nested fields are separated by dots.
+// Indexes shall be defined before the component is opened. Items stored earlier are not indexed.
+// When the existing RediSearch index has other fields, Open returns ConfigError.
+// Parameters:
+//   - field         a name of the indexed field.
+//   - indexType     a type of the index: StringIndex or NumberIndex.
+func (c *RedisPersistence[T]) EnsureIndex(field string, indexType IndexType) {
+	if indexType != NumberIndex {
+		indexType = StringIndex
+	}
+
+	for _, index := range c.indexes {
+		if index.Field == field {
+			index.Type = indexType
+			return
+		}
+	}
+	c.indexes = append(c.indexes, &indexDefinition{Field: field, Type: indexType})
+}
+
+// GetPageByFilter method are gets a page of data items filtered by indexed fields and sorted by them.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - filter            (optional) values of indexed fields the items must be equal to.
+//   - paging            (optional) paging parameters.
+//   - sort              (optional) indexed fields to sort the items.
+// Returns: a requested data page or error. BadRequestError is returned when the filter or sort refer not indexed fields.
+func (c *RedisPersistence[T]) GetPageByFilter(ctx context.Context, correlationId string,
+	filter *cdata.FilterParams, paging *cdata.PagingParams, sort *cdata.SortParams) (cdata.DataPage[T], error) {
+
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return *cdata.NewEmptyDataPage[T](), err
+	}
+
+	if filter == nil {
+		filter = cdata.NewEmptyFilterParams()
+	}
+	if paging == nil {
+		paging = cdata.NewEmptyPagingParams()
+	}
+	if sort == nil {
+		sort = cdata.NewEmptySortParams()
+	}
+
+	for _, field := range filter.Keys() {
+		if err = c.checkIndex(correlationId, field); err != nil {
+			return *cdata.NewEmptyDataPage[T](), err
+		}
+	}
+	for _, field := range *sort {
+		if err = c.checkIndex(correlationId, field.Name); err != nil {
+			return *cdata.NewEmptyDataPage[T](), err
+		}
+	}
+
+	skip := paging.GetSkip(0)
+	take := paging.GetTake(c.MaxPageSize)
+
+	var items []T
+	var total int64
+	if c.searchModule {
+		items, total, err = c.searchItems(filter, skip, take, sort)
+	} else {
+		items, total, err = c.queryItems(filter, skip, take, sort)
+	}
+	if err != nil {
+		return *cdata.NewEmptyDataPage[T](), err
+	}
+
+	c.Logger.Trace(ctx, correlationId, "Retrieved %d from %s", len(items), c.CollectionName)
+
+	if paging.Total {
+		return *cdata.NewDataPage[T](items, int(total)), nil
+	}
+	return *cdata.NewDataPage[T](items, cdata.EmptyTotalValue), nil
+}
+
+func (c *RedisPersistence[T]) getIndexKey(field string) string {
+	return c.getKeyPrefix() + "index:" + field
+}
+
+func (c *RedisPersistence[T]) getSearchIndexName() string {
+	return c.getKeyPrefix() + "search"
+}
+
+func (c *RedisPersistence[T]) findIndex(field string) *indexDefinition {
+	for _, index := range c.indexes {
+		if index.Field == field {
+			return index
+		}
+	}
+	return nil
+}
+
+func (c *RedisPersistence[T]) checkIndex(correlationId string, field string) error {
+	if c.findIndex(field) == nil {
+		return cerr.NewBadRequestError(correlationId, "NOT_INDEXED",
+			"Field "+field+" is not indexed in "+c.CollectionName).
+			WithDetails("field", field)
+	}
+	return nil
+}
+
+// getIndexMember gets a member of the index for the field value of the item.
+// For number indexes the value is kept in the score.
+func (c *RedisPersistence[T]) getIndexMember(index *indexDefinition, id string, values map[string]any) (redis.Z, bool) {
+	var value any = values
+	for _, name := range strings.Split(index.Field, ".") {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return redis.Z{}, false
+		}
+		if value, ok = fields[name]; !ok || value == nil {
+			return redis.Z{}, false
+		}
+	}
+
+	if index.Type == NumberIndex {
+		score, ok := cconv.DoubleConverter.ToNullableDouble(value)
+		if !ok {
+			return redis.Z{}, false
+		}
+		return redis.Z{Score: score, Member: id}, true
+	}
+	return redis.Z{Score: 0, Member: cconv.StringConverter.ToString(value) + indexSeparator + id}, true
+}
+
+// updateIndexes adds commands to replace index entries of the previous serialized item
+// with entries of the new one into the pipeline. An empty serialized item has no entries.
+func (c *RedisPersistence[T]) updateIndexes(pipe redis.Pipeliner, id string, oldData string, data string) error {
+	// Sorted sets are kept even with RediSearch to sort items by multiple fields
+	if len(c.indexes) == 0 {
+		return nil
+	}
+
+	var oldValues, values map[string]any
+	if oldData != "" {
+		if err := json.Unmarshal([]byte(oldData), &oldValues); err != nil {
+			return err
+		}
+	}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &values); err != nil {
+			return err
+		}
+	}
+
+	for _, index := range c.indexes {
+		oldMember, oldOk := c.getIndexMember(index, id, oldValues)
+		member, ok := c.getIndexMember(index, id, values)
+		if oldOk && ok && oldMember == member {
+			continue
+		}
+		if oldOk {
+			pipe.ZRem(c.getIndexKey(index.Field), oldMember.Member)
+		}
+		if ok {
+			pipe.ZAdd(c.getIndexKey(index.Field), member)
+		}
+	}
+	return nil
+}
+
+// queryItems filters and sorts ids of items with the sorted set indexes and reads the requested page.
+func (c *RedisPersistence[T]) queryItems(filter *cdata.FilterParams, skip int64, take int64,
+	sortParams *cdata.SortParams) ([]T, int64, error) {
+
+	client := c.Connection.GetClient()
+
+	var ids []string
+	var err error
+	if filter.Len() == 0 {
+		ids, err = client.SMembers(c.getIdsKey()).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	for i, field := range filter.Keys() {
+		matched, err := c.queryIndex(c.findIndex(field), filter.GetAsString(field))
+		if err != nil {
+			return nil, 0, err
+		}
+		if i == 0 {
+			ids = matched
+		} else {
+			ids = intersectIds(ids, matched)
+		}
+	}
+
+	// Items are ordered by ranks of values in indexes and then by ids
+	ranks := make([]map[string]int, len(*sortParams))
+	for i, field := range *sortParams {
+		ranks[i], err = c.rankIndex(c.findIndex(field.Name))
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		for k, field := range *sortParams {
+			// Items without values go first
+			rank1, ok1 := ranks[k][ids[i]]
+			if !ok1 {
+				rank1 = -1
+			}
+			rank2, ok2 := ranks[k][ids[j]]
+			if !ok2 {
+				rank2 = -1
+			}
+			if rank1 != rank2 {
+				return (rank1 < rank2) == field.Ascending
+			}
+		}
+		return ids[i] < ids[j]
+	})
+
+	total := int64(len(ids))
+	if skip >= total {
+		return []T{}, total, nil
+	}
+	end := skip + take
+	if end > total {
+		end = total
+	}
+
+	items, err := c.readItems(ids[skip:end])
+	return items, total, err
+}
+
+// queryIndex gets ids of items with the field value equal to the given one.
+func (c *RedisPersistence[T]) queryIndex(index *indexDefinition, value string) ([]string, error) {
+	client := c.Connection.GetClient()
+
+	if index.Type == NumberIndex {
+		score, ok := cconv.DoubleConverter.ToNullableDouble(value)
+		if !ok {
+			return []string{}, nil
+		}
+		bound := strconv.FormatFloat(score, 'g', -1, 64)
+		return client.ZRangeByScore(c.getIndexKey(index.Field), redis.ZRangeBy{Min: bound, Max: bound}).Result()
+	}
+
+	members, err := client.ZRangeByLex(c.getIndexKey(index.Field), redis.ZRangeBy{
+		Min: "[" + value + indexSeparator,
+		Max: "(" + value + "\x01",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member[len(value)+len(indexSeparator):]
+	}
+	return ids, nil
+}
+
+// rankIndex gets ranks of field values by item ids. Items with equal values have the same rank.
+func (c *RedisPersistence[T]) rankIndex(index *indexDefinition) (map[string]int, error) {
+	members, err := c.Connection.GetClient().ZRangeWithScores(c.getIndexKey(index.Field), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make(map[string]int, len(members))
+	rank := 0
+	var previous any
+	for i, member := range members {
+		id, _ := member.Member.(string)
+		var value any = member.Score
+		if index.Type == StringIndex {
+			sep := strings.LastIndex(id, indexSeparator)
+			if sep < 0 {
+				continue
+			}
+			value, id = id[:sep], id[sep+len(indexSeparator):]
+		}
+		if i > 0 && value != previous {
+			rank++
+		}
+		previous = value
+		ranks[id] = rank
+	}
+	return ranks, nil
+}
+
+func intersectIds(ids []string, other []string) []string {
+	set := make(map[string]bool, len(other))
+	for _, id := range other {
+		set[id] = true
+	}
+
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if set[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// hasSearchModule checks if the RediSearch module is loaded on the server.
+func (c *RedisPersistence[T]) hasSearchModule() bool {
+	cmd := redis.NewSliceCmd("MODULE", "LIST")
+	c.Connection.GetClient().Process(cmd)
+	modules, err := cmd.Result()
+	if err != nil {
+		return false
+	}
+
+	for _, module := range modules {
+		properties, _ := module.([]any)
+		for i := 0; i+1 < len(properties); i += 2 {
+			name, _ := properties[i].(string)
+			value, _ := properties[i+1].(string)
+			if name == "name" && (strings.EqualFold(value, "search") || strings.EqualFold(value, "ft")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// createSearchIndex creates the RediSearch index over JSON items unless it already exists.
+// Tags are case-sensitive to match values in the same way as sorted set indexes.
+func (c *RedisPersistence[T]) createSearchIndex(correlationId string) error {
+	args := []any{"FT.CREATE", c.getSearchIndexName(), "ON", "JSON",
+		"PREFIX", 1, c.getItemKey(""), "SCHEMA"}
+	for _, index := range c.indexes {
+		args = append(args, "$."+index.Field, "AS", c.getSearchField(index.Field))
+		if index.Type == NumberIndex {
+			args = append(args, "NUMERIC", "SORTABLE")
+		} else {
+			args = append(args, "TAG", "SORTABLE", "CASESENSITIVE")
+		}
+	}
+
+	cmd := redis.NewStatusCmd(args...)
+	c.Connection.GetClient().Process(cmd)
+	err := cmd.Err()
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "already exists") {
+		return c.checkSearchIndex(correlationId)
+	}
+	if err != nil {
+		return cerr.NewConnectionError(correlationId, "CREATE_INDEX_FAILED",
+			"Failed to create search index for "+c.CollectionName).
+			WithCause(err)
+	}
+	return nil
+}
+
+// checkSearchIndex compares fields of the existing RediSearch index with the indexed fields.
+// The existing index is not changed, so ConfigError is returned when the fields differ.
+func (c *RedisPersistence[T]) checkSearchIndex(correlationId string) error {
+	cmd := redis.NewSliceCmd("FT.INFO", c.getSearchIndexName())
+	c.Connection.GetClient().Process(cmd)
+	info, err := cmd.Result()
+	if err != nil {
+		return cerr.NewConnectionError(correlationId, "CREATE_INDEX_FAILED",
+			"Failed to read search index for "+c.CollectionName).
+			WithCause(err)
+	}
+
+	// Fields are described by lists of properties and flags,
+	// e.g. "identifier", "$.name", "attribute", "name", "type", "TAG", "SORTABLE", "CASESENSITIVE"
+	actual := map[string]string{}
+	for i := 0; i+1 < len(info); i += 2 {
+		name, _ := info[i].(string)
+		if name != "attributes" && name != "fields" {
+			continue
+		}
+		fields, _ := info[i+1].([]any)
+		for _, field := range fields {
+			properties, _ := field.([]any)
+			values := map[string]string{}
+			caseSensitive := false
+			for j, property := range properties {
+				property, _ := property.(string)
+				if property == "CASESENSITIVE" {
+					caseSensitive = true
+				} else if j+1 < len(properties) {
+					values[property], _ = properties[j+1].(string)
+				}
+			}
+			actual[values["attribute"]] = getSearchFieldSchema(values["identifier"], values["type"], caseSensitive)
+		}
+	}
+
+	expected := make(map[string]string, len(c.indexes))
+	for _, index := range c.indexes {
+		if index.Type == NumberIndex {
+			expected[c.getSearchField(index.Field)] = getSearchFieldSchema("$."+index.Field, "NUMERIC", false)
+		} else {
+			expected[c.getSearchField(index.Field)] = getSearchFieldSchema("$."+index.Field, "TAG", true)
+		}
+	}
+
+	changed := len(actual) != len(expected)
+	for attribute, schema := range expected {
+		if actual[attribute] != schema {
+			changed = true
+		}
+	}
+	if changed {
+		return cerr.NewConfigError(correlationId, "SEARCH_INDEX_CHANGED",
+			"Search index "+c.getSearchIndexName()+" has other fields than indexes of "+c.CollectionName+
+				", drop it with FT.DROPINDEX to recreate").
+			WithDetails("index", c.getSearchIndexName())
+	}
+	return nil
+}
+
+func getSearchFieldSchema(identifier string, fieldType string, caseSensitive bool) string {
+	return identifier + " " + strings.ToUpper(fieldType) + " " + strconv.FormatBool(caseSensitive)
+}
+
+func (c *RedisPersistence[T]) getSearchField(field string) string {
+	return strings.ReplaceAll(field, ".", "_")
+}
+
+// searchItems filters and sorts items with FT.AGGREGATE and reads the requested page.
+func (c *RedisPersistence[T]) searchItems(filter *cdata.FilterParams, skip int64, take int64,
+	sortParams *cdata.SortParams) ([]T, int64, error) {
+
+	conditions := make([]string, 0, filter.Len())
+	for _, field := range filter.Keys() {
+		index := c.findIndex(field)
+		value := filter.GetAsString(field)
+		name := "@" + c.getSearchField(field)
+
+		if index.Type == NumberIndex {
+			score, ok := cconv.DoubleConverter.ToNullableDouble(value)
+			if !ok {
+				return []T{}, 0, nil
+			}
+			bound := strconv.FormatFloat(score, 'g', -1, 64)
+			conditions = append(conditions, name+":["+bound+" "+bound+"]")
+		} else {
+			conditions = append(conditions, name+":{"+escapeTag(value)+"}")
+		}
+	}
+	query := "*"
+	if len(conditions) > 0 {
+		query = strings.Join(conditions, " ")
+	}
+
+	client := c.Connection.GetClient()
+
+	countCmd := redis.NewSliceCmd("FT.SEARCH", c.getSearchIndexName(), query, "LIMIT", 0, 0)
+	client.Process(countCmd)
+	result, err := countCmd.Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(result) == 0 {
+		return []T{}, 0, nil
+	}
+	total, _ := result[0].(int64)
+
+	// Items are ordered by fields and then by keys that differ only in ids like in sorted set indexes
+	sortArgs := make([]any, 0, 2*len(*sortParams)+2)
+	for _, field := range *sortParams {
+		direction := "ASC"
+		if !field.Ascending {
+			direction = "DESC"
+		}
+		sortArgs = append(sortArgs, "@"+c.getSearchField(field.Name), direction)
+	}
+	sortArgs = append(sortArgs, "@__key", "ASC")
+
+	args := []any{"FT.AGGREGATE", c.getSearchIndexName(), query, "LOAD", 1, "@__key", "SORTBY", len(sortArgs)}
+	args = append(args, sortArgs...)
+	args = append(args, "LIMIT", skip, take)
+
+	cmd := redis.NewSliceCmd(args...)
+	client.Process(cmd)
+	result, err = cmd.Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	prefix := c.getItemKey("")
+	ids := make([]string, 0, len(result))
+	// The result contains a number of rows followed by lists of row fields and values
+	for i := 1; i < len(result); i++ {
+		fields, _ := result[i].([]any)
+		for j := 0; j+1 < len(fields); j += 2 {
+			name, _ := fields[j].(string)
+			key, _ := fields[j+1].(string)
+			if name == "__key" && strings.HasPrefix(key, prefix) {
+				ids = append(ids, key[len(prefix):])
+			}
+		}
+	}
+
+	items, err := c.readItems(ids)
+	return items, total, err
+}
+
+// escapeTag escapes punctuation and spaces in a value of a tag query.
+func escapeTag(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(",.<>{}[]\"':;!@#$%^&*()-+=~|/\\ ", r) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
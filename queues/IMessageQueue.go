@@ -0,0 +1,38 @@
+package queues
+
+import "context"
+
+// IMessageQueue interface to handle received messages. Receivers use it to complete, abandon
+// or dead-letter messages without depending on a particular queue implementation.
+// It mirrors the message handling methods of IMessageQueue from github.com/pip-services3-gox/pip-services3-messaging-gox.
+type IMessageQueue interface {
+
+	// RenewLock renews a lock on a message to prevent it from being redelivered to another consumer.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- message       a message to extend its lock.
+	//		- lockTimeout   a locking timeout in milliseconds.
+	//	Returns: error or nil for success.
+	RenewLock(ctx context.Context, message *MessageEnvelope, lockTimeout int64) error
+
+	// Complete permanently removes a message from the queue.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- message   a message to remove.
+	//	Returns: error or nil for success.
+	Complete(ctx context.Context, message *MessageEnvelope) error
+
+	// Abandon returns a message into the queue, so it can be received again.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- message   a message to return.
+	//	Returns: error or nil for success.
+	Abandon(ctx context.Context, message *MessageEnvelope) error
+
+	// MoveToDeadLetter permanently removes a message from the queue and sends it to the dead letter queue.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- message   a message to be removed.
+	//	Returns: error or nil for success.
+	MoveToDeadLetter(ctx context.Context, message *MessageEnvelope) error
+}
@@ -0,0 +1,18 @@
+package queues
+
+import "context"
+
+// IMessageReceiver callback interface to receive incoming messages.
+// It mirrors IMessageReceiver from github.com/pip-services3-gox/pip-services3-messaging-gox,
+// so receivers do not depend on RedisMessageQueue.
+type IMessageReceiver interface {
+
+	// ReceiveMessage receives incoming message from the queue.
+	// The receiver is responsible to complete or abandon the message.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- envelope  an incoming message
+	//		- queue     a queue where the message comes from
+	//	Returns: error or nil for success.
+	ReceiveMessage(ctx context.Context, envelope *MessageEnvelope, queue IMessageQueue) error
+}
@@ -0,0 +1,75 @@
+package queues
+
+import (
+	"encoding/json"
+	"time"
+
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+)
+
+// MessageEnvelope allows adding additional information to messages.
+// A correlation id, message id, and a message type are added to the data being sent/received.
+// Additionally, a MessageEnvelope can reference a broker-specific message.
+type MessageEnvelope struct {
+	CorrelationId string    `json:"correlation_id"` // An id that is used to trace execution through call chain
+	MessageId     string    `json:"message_id"`     // A unique id of the message
+	MessageType   string    `json:"message_type"`   // A type of the message
+	SentTime      time.Time `json:"sent_time"`      // A time when the message was sent
+	Message       []byte    `json:"message"`        // A content of the message
+
+	reference any
+}
+
+// NewEmptyMessageEnvelope method are creates an empty message envelope.
+func NewEmptyMessageEnvelope() *MessageEnvelope {
+	return &MessageEnvelope{}
+}
+
+// NewMessageEnvelope method are creates a new message envelope with a generated message id.
+// Parameters:
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - messageType       a message type.
+//   - message           a message content.
+func NewMessageEnvelope(correlationId string, messageType string, message []byte) *MessageEnvelope {
+	return &MessageEnvelope{
+		CorrelationId: correlationId,
+		MessageId:     cdata.IdGenerator.NextLong(),
+		MessageType:   messageType,
+		Message:       message,
+	}
+}
+
+// GetReference method are gets a broker-specific reference to the original message.
+func (c *MessageEnvelope) GetReference() any {
+	return c.reference
+}
+
+// SetReference method are sets a broker-specific reference to the original message.
+func (c *MessageEnvelope) SetReference(value any) {
+	c.reference = value
+}
+
+// GetMessageAsString method are gets the message content as a string.
+func (c *MessageEnvelope) GetMessageAsString() string {
+	return string(c.Message)
+}
+
+// SetMessageAsString method are sets the message content from a string.
+func (c *MessageEnvelope) SetMessageAsString(value string) {
+	c.Message = []byte(value)
+}
+
+// GetMessageAsJson method are decodes the message content from JSON into the value.
+func (c *MessageEnvelope) GetMessageAsJson(value any) error {
+	return json.Unmarshal(c.Message, value)
+}
+
+// SetMessageAsJson method are encodes the value into JSON and sets it as the message content.
+func (c *MessageEnvelope) SetMessageAsJson(value any) error {
+	message, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.Message = message
+	return nil
+}
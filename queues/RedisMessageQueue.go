@@ -0,0 +1,689 @@
+package queues
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisMessageQueue is a message queue that is implemented based on Redis Streams.
+Messages are appended to a stream and delivered to the members of a consumer group,
+so every message is processed by a single consumer across all service instances.
+
+Received messages stay pending until they are completed. Messages that are not completed
+within the visibility timeout, or that were abandoned, are redelivered to the next receiver.
+After the maximum number of deliveries a message is moved to the dead letter queue.
+
+Configuration parameters:
+
+  - name:                    name of the stream (default: "queue")
+  - options:
+    - group:                 name of the consumer group (default: "default")
+    - consumer:              name of the consumer in the group (default: generated unique id)
+    - max_length:            approximate maximum number of messages kept in the stream (default: 0 - unlimited)
+    - max_deliveries:        number of deliveries before a message is moved to the dead letter queue (default: 5)
+    - dead_letter_queue:     name of the dead letter stream (default: name + ":dead")
+    - visibility_timeout:    timeout in milliseconds after which a received message is redelivered (default: 30000)
+    - listen_timeout:        timeout in milliseconds to wait for messages while listening (default: 1000)
+    - claim_batch_size:      number of pending messages checked by a single receive while looking for stale messages (default: 100)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    queue := NewRedisMessageQueue("myqueue");
+    queue.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.group", "workers",
+    ));
+
+    err = queue.Open(ctx, "123")
+      ...
+
+    err = queue.Send(ctx, "123", NewMessageEnvelope("", "mymessage", []byte("ABC")))
+
+    message, err := queue.Receive(ctx, "123", 10000)
+    if message != nil {
+    	// Processing...
+    	err = queue.Complete(ctx, message)
+    }
+*/
+type RedisMessageQueue struct {
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	name              string
+	group             string
+	consumer          string
+	maxLength         int64
+	maxDeliveries     int64
+	deadLetterQueue   string
+	visibilityTimeout int64
+	listenTimeout     int64
+	claimBatchSize    int64
+	claimCursor       string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mtx    sync.Mutex
+}
+
+// NewRedisMessageQueue method are creates a new instance of the message queue.
+// Parameters:
+//   - name  (optional) a name of the stream.
+func NewRedisMessageQueue(name string) *RedisMessageQueue {
+	if name == "" {
+		name = "queue"
+	}
+	return &RedisMessageQueue{
+		connection:        rconnect.NewRedisConnection(),
+		logger:            *clog.NewCompositeLogger(),
+		name:              name,
+		group:             "default",
+		consumer:          cdata.IdGenerator.NextLong(),
+		maxLength:         0,
+		maxDeliveries:     5,
+		visibilityTimeout: 30000,
+		listenTimeout:     1000,
+		claimBatchSize:    100,
+		claimCursor:       "-",
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisMessageQueue) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.name = config.GetAsStringWithDefault("name", c.name)
+	c.name = config.GetAsStringWithDefault("queue", c.name)
+	c.group = config.GetAsStringWithDefault("options.group", c.group)
+	c.consumer = config.GetAsStringWithDefault("options.consumer", c.consumer)
+	c.maxLength = config.GetAsLongWithDefault("options.max_length", c.maxLength)
+	c.maxDeliveries = config.GetAsLongWithDefault("options.max_deliveries", c.maxDeliveries)
+	if c.maxDeliveries < 1 {
+		c.maxDeliveries = 1
+	}
+	c.deadLetterQueue = config.GetAsStringWithDefault("options.dead_letter_queue", c.deadLetterQueue)
+	c.visibilityTimeout = config.GetAsLongWithDefault("options.visibility_timeout", c.visibilityTimeout)
+	c.listenTimeout = config.GetAsLongWithDefault("options.listen_timeout", c.listenTimeout)
+	c.claimBatchSize = config.GetAsLongWithDefault("options.claim_batch_size", c.claimBatchSize)
+	if c.claimBatchSize < 1 {
+		c.claimBatchSize = 1
+	}
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisMessageQueue) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// GetName method are gets the name of the stream.
+func (c *RedisMessageQueue) GetName() string {
+	return c.name
+}
+
+// GetDeadLetterQueue method are gets the name of the dead letter stream.
+func (c *RedisMessageQueue) GetDeadLetterQueue() string {
+	if c.deadLetterQueue == "" {
+		return c.name + ":dead"
+	}
+	return c.deadLetterQueue
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisMessageQueue) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component and creates the consumer group when it does not exist.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisMessageQueue) Open(ctx context.Context, correlationId string) error {
+	err := c.connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	err = c.createGroup()
+	if err != nil {
+		c.connection.Close(ctx, correlationId)
+		return err
+	}
+	return nil
+}
+
+// Close method are stops listening, closes component and frees used resources.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisMessageQueue) Close(ctx context.Context, correlationId string) error {
+	c.EndListen(ctx, correlationId)
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisMessageQueue) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisMessageQueue) createGroup() error {
+	err := c.connection.GetClient().XGroupCreateMkStream(c.name, c.group, "0").Err()
+	if err != nil && strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+	return err
+}
+
+// Clear method are removes all messages from the stream and recreates the consumer group.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) Clear(ctx context.Context, correlationId string) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	err = c.connection.GetClient().Del(c.name).Err()
+	if err != nil {
+		return err
+	}
+	return c.createGroup()
+}
+
+// ReadMessageCount method are reads the current number of messages in the stream,
+// including the received messages that were not completed yet.
+// Parameters:
+//  - ctx context.Context
+// Returns: a number of messages or error.
+func (c *RedisMessageQueue) ReadMessageCount(ctx context.Context) (int64, error) {
+	state, err := c.checkOpened("")
+	if !state {
+		return 0, err
+	}
+
+	return c.connection.GetClient().XLen(c.name).Result()
+}
+
+// Send method are sends a message into the stream.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - envelope          a message envelop to be sent.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) Send(ctx context.Context, correlationId string, envelope *MessageEnvelope) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	if envelope.MessageId == "" {
+		envelope.MessageId = cdata.IdGenerator.NextLong()
+	}
+	if envelope.CorrelationId == "" {
+		envelope.CorrelationId = correlationId
+	}
+	envelope.SentTime = time.Now().UTC()
+
+	id, err := c.connection.GetClient().XAdd(&redis.XAddArgs{
+		Stream:       c.name,
+		MaxLenApprox: c.maxLength,
+		Values:       c.fromMessage(envelope),
+	}).Result()
+	if err != nil {
+		return err
+	}
+	envelope.SetReference(id)
+
+	c.logger.Debug(ctx, envelope.CorrelationId, "Sent message %s via %s", envelope.MessageId, c.name)
+	return nil
+}
+
+// Peek method are peeks a single incoming message from the stream without removing it.
+// If there are no messages available in the stream it returns nil.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns: a peeked message or error.
+func (c *RedisMessageQueue) Peek(ctx context.Context, correlationId string) (*MessageEnvelope, error) {
+	messages, err := c.PeekBatch(ctx, correlationId, 1)
+	if err != nil || len(messages) == 0 {
+		return nil, err
+	}
+	return messages[0], nil
+}
+
+// PeekBatch method are peeks multiple incoming messages from the stream without removing them.
+// If there are no messages available in the stream it returns an empty list.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - messageCount      a maximum number of messages to peek.
+// Returns: a list with peeked messages or error.
+func (c *RedisMessageQueue) PeekBatch(ctx context.Context, correlationId string, messageCount int64) ([]*MessageEnvelope, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	items, err := c.connection.GetClient().XRangeN(c.name, "-", "+", messageCount).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*MessageEnvelope, 0, len(items))
+	for _, item := range items {
+		messages = append(messages, c.toMessage(item))
+	}
+	return messages, nil
+}
+
+// Receive method are receives an incoming message and locks it until it is completed or abandoned.
+// Stale messages of other consumers are redelivered first.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - waitTimeout       a timeout in milliseconds to wait for a message to come.
+// Returns: a received message, nil if no messages came during the timeout, or error.
+func (c *RedisMessageQueue) Receive(ctx context.Context, correlationId string, waitTimeout int64) (*MessageEnvelope, error) {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return nil, err
+	}
+
+	message, err := c.claimStale(ctx, correlationId)
+	if message != nil || err != nil {
+		return message, err
+	}
+
+	block := time.Duration(-1)
+	if waitTimeout > 0 {
+		block = time.Duration(waitTimeout) * time.Millisecond
+	}
+
+	streams, err := c.connection.GetClient().XReadGroup(&redis.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.consumer,
+		Streams:  []string{c.name, ">"},
+		Count:    1,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stream := range streams {
+		for _, item := range stream.Messages {
+			return c.toMessage(item), nil
+		}
+	}
+	return nil, nil
+}
+
+// claimStale claims a message that stays pending longer than the visibility timeout.
+// Every call checks a batch of the pending list from the place where the previous call stopped,
+// and wraps around once when it reaches the end. So receiving costs the same regardless of the number
+// of messages in flight. Messages that exceeded the maximum number of deliveries are moved
+// to the dead letter queue on the way.
+func (c *RedisMessageQueue) claimStale(ctx context.Context, correlationId string) (*MessageEnvelope, error) {
+	client := c.connection.GetClient()
+	minIdle := time.Duration(c.visibilityTimeout) * time.Millisecond
+
+	c.mtx.Lock()
+	start := c.claimCursor
+	c.mtx.Unlock()
+
+	next := start
+	defer func() {
+		c.mtx.Lock()
+		c.claimCursor = next
+		c.mtx.Unlock()
+	}()
+
+	for {
+		pending, err := client.XPendingExt(&redis.XPendingExtArgs{
+			Stream: c.name,
+			Group:  c.group,
+			Start:  start,
+			End:    "+",
+			Count:  c.claimBatchSize,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range pending {
+			if entry.Idle < minIdle {
+				continue
+			}
+
+			items, err := client.XClaim(&redis.XClaimArgs{
+				Stream:   c.name,
+				Group:    c.group,
+				Consumer: c.consumer,
+				MinIdle:  minIdle,
+				Messages: []string{entry.Id},
+			}).Result()
+			if err != nil {
+				return nil, err
+			}
+			// The message was claimed by another consumer or deleted
+			if len(items) == 0 || items[0].Values == nil {
+				continue
+			}
+
+			message := c.toMessage(items[0])
+			// The claim above is the next delivery of the message
+			if entry.RetryCount >= c.maxDeliveries {
+				c.logger.Warn(ctx, correlationId, "Message %s exceeded %d deliveries in %s", message.MessageId, c.maxDeliveries, c.name)
+				err = c.MoveToDeadLetter(ctx, message)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			next = nextStreamId(entry.Id)
+			return message, nil
+		}
+
+		if int64(len(pending)) == c.claimBatchSize {
+			next = nextStreamId(pending[len(pending)-1].Id)
+			return nil, nil
+		}
+
+		// The end of the pending list is reached, the next check starts over
+		next = "-"
+		if start == "-" {
+			return nil, nil
+		}
+		start = "-"
+	}
+}
+
+// nextStreamId gets the smallest stream id greater than the given one to continue a range.
+func nextStreamId(id string) string {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return id
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return id
+	}
+	return parts[0] + "-" + strconv.FormatUint(seq+1, 10)
+}
+
+// RenewLock method are renews a lock on a message to prevent it from being redelivered to another consumer.
+// Parameters:
+//  - ctx context.Context
+//  - message       a message to extend its lock.
+//  - lockTimeout   a locking timeout in milliseconds (not used, the lock is extended by the visibility timeout).
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) RenewLock(ctx context.Context, message *MessageEnvelope, lockTimeout int64) error {
+	state, err := c.checkOpened("")
+	if !state {
+		return err
+	}
+
+	id := c.getReference(message)
+	if id == "" {
+		return nil
+	}
+	return c.setIdle(id, 0)
+}
+
+// Complete method are permanently removes a message from the stream.
+// This method is usually used to remove the message after successful processing.
+// Parameters:
+//  - ctx context.Context
+//  - message   a message to remove.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) Complete(ctx context.Context, message *MessageEnvelope) error {
+	state, err := c.checkOpened("")
+	if !state {
+		return err
+	}
+
+	id := c.getReference(message)
+	if id == "" {
+		return nil
+	}
+
+	pipe := c.connection.GetClient().TxPipeline()
+	pipe.XAck(c.name, c.group, id)
+	pipe.XDel(c.name, id)
+	_, err = pipe.Exec()
+	if err != nil {
+		return err
+	}
+	message.SetReference(nil)
+	return nil
+}
+
+// Abandon method are returns a message into the stream and makes it available for all subscribers to receive it again.
+// This method is usually used to return a message which could not be processed at the moment
+// to repeat the attempt. Messages that cause unrecoverable errors shall be removed permanently
+// or/and send to dead letter queue.
+// Parameters:
+//  - ctx context.Context
+//  - message   a message to return.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) Abandon(ctx context.Context, message *MessageEnvelope) error {
+	state, err := c.checkOpened("")
+	if !state {
+		return err
+	}
+
+	id := c.getReference(message)
+	if id == "" {
+		return nil
+	}
+
+	// Mark the message as stale, so the next receiver claims it right away
+	err = c.setIdle(id, c.visibilityTimeout)
+	if err != nil {
+		return err
+	}
+	message.SetReference(nil)
+	return nil
+}
+
+// MoveToDeadLetter method are permanently removes a message from the stream and sends it to the dead letter queue.
+// Parameters:
+//  - ctx context.Context
+//  - message   a message to be removed.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) MoveToDeadLetter(ctx context.Context, message *MessageEnvelope) error {
+	state, err := c.checkOpened("")
+	if !state {
+		return err
+	}
+
+	id := c.getReference(message)
+	if id == "" {
+		return nil
+	}
+
+	values := c.fromMessage(message)
+	values["original_id"] = id
+
+	// The streams may belong to different slots in a cluster, so they are not updated in a transaction
+	pipe := c.connection.GetClient().Pipeline()
+	pipe.XAdd(&redis.XAddArgs{
+		Stream:       c.GetDeadLetterQueue(),
+		MaxLenApprox: c.maxLength,
+		Values:       values,
+	})
+	pipe.XAck(c.name, c.group, id)
+	pipe.XDel(c.name, id)
+	_, err = pipe.Exec()
+	if err != nil {
+		return err
+	}
+	message.SetReference(nil)
+	return nil
+}
+
+// Listen method are listens for incoming messages and blocks the current thread until the queue is closed
+// or listening is stopped.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - receiver          a receiver to receive incoming messages.
+// Returns: error or nil for success.
+func (c *RedisMessageQueue) Listen(ctx context.Context, correlationId string, receiver IMessageReceiver) error {
+	c.mtx.Lock()
+	if c.done != nil {
+		c.mtx.Unlock()
+		return cerr.NewInvalidStateError(correlationId, "ALREADY_LISTENING", "Queue "+c.name+" is already listening")
+	}
+	listenCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	c.cancel = cancel
+	c.done = done
+	c.mtx.Unlock()
+
+	defer close(done)
+
+	c.logger.Trace(ctx, correlationId, "Started listening messages at %s", c.name)
+
+	for listenCtx.Err() == nil && c.IsOpen() {
+		message, err := c.Receive(listenCtx, correlationId, c.listenTimeout)
+		if err != nil {
+			if listenCtx.Err() != nil {
+				break
+			}
+			c.logger.Error(ctx, correlationId, err, "Failed to receive the message at %s", c.name)
+			select {
+			case <-listenCtx.Done():
+			case <-time.After(time.Duration(c.listenTimeout) * time.Millisecond):
+			}
+			continue
+		}
+		if message == nil {
+			continue
+		}
+
+		err = receiver.ReceiveMessage(listenCtx, message, c)
+		if err != nil {
+			c.logger.Error(ctx, message.CorrelationId, err, "Failed to process the message %s", message.MessageId)
+			if message.GetReference() != nil {
+				if err = c.Abandon(ctx, message); err != nil {
+					c.logger.Error(ctx, message.CorrelationId, err, "Failed to abandon the message %s", message.MessageId)
+				}
+			}
+		}
+	}
+
+	c.logger.Trace(ctx, correlationId, "Stopped listening messages at %s", c.name)
+	return nil
+}
+
+// BeginListen method are listens for incoming messages without blocking the current thread.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - receiver          a receiver to receive incoming messages.
+func (c *RedisMessageQueue) BeginListen(ctx context.Context, correlationId string, receiver IMessageReceiver) {
+	go func() {
+		err := c.Listen(context.Background(), correlationId, receiver)
+		if err != nil {
+			c.logger.Error(ctx, correlationId, err, "Failed to listen messages at %s", c.name)
+		}
+	}()
+}
+
+// EndListen method are ends listening for incoming messages.
+// When this method is call Listen unblocks the thread and execution continues.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+func (c *RedisMessageQueue) EndListen(ctx context.Context, correlationId string) {
+	c.mtx.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel = nil
+	c.done = nil
+	c.mtx.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// setIdle resets the idle time of a pending message without incrementing its delivery counter.
+func (c *RedisMessageQueue) setIdle(id string, idle int64) error {
+	cmd := redis.NewCmd("XCLAIM", c.name, c.group, c.consumer, 0, id, "IDLE", idle, "JUSTID")
+	c.connection.GetClient().Process(cmd)
+	return cmd.Err()
+}
+
+func (c *RedisMessageQueue) getReference(message *MessageEnvelope) string {
+	if message == nil {
+		return ""
+	}
+	id, _ := message.GetReference().(string)
+	return id
+}
+
+func (c *RedisMessageQueue) fromMessage(envelope *MessageEnvelope) map[string]any {
+	return map[string]any{
+		"correlation_id": envelope.CorrelationId,
+		"message_id":     envelope.MessageId,
+		"message_type":   envelope.MessageType,
+		"sent_time":      cconv.StringConverter.ToString(envelope.SentTime),
+		"message":        envelope.Message,
+	}
+}
+
+func (c *RedisMessageQueue) toMessage(item redis.XMessage) *MessageEnvelope {
+	envelope := NewEmptyMessageEnvelope()
+	envelope.CorrelationId = cconv.StringConverter.ToString(item.Values["correlation_id"])
+	envelope.MessageId = cconv.StringConverter.ToString(item.Values["message_id"])
+	envelope.MessageType = cconv.StringConverter.ToString(item.Values["message_type"])
+	envelope.SentTime = cconv.DateTimeConverter.ToDateTime(item.Values["sent_time"])
+	envelope.Message = []byte(cconv.StringConverter.ToString(item.Values["message"]))
+	envelope.SetReference(item.ID)
+	return envelope
+}
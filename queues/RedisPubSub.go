@@ -0,0 +1,325 @@
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+// PubSubHandler is a callback function to handle messages published to subscribed channels.
+//	Parameters:
+//		- ctx context.Context
+//		- channel   a channel where the message was published
+//		- envelope  a published message
+//	Returns: error or nil for success.
+type PubSubHandler func(ctx context.Context, channel string, envelope *MessageEnvelope) error
+
+/*
+RedisPubSub is a message bus for fire-and-forget notifications that is implemented based on Redis Pub/Sub.
+Messages are published as JSON-encoded envelopes and delivered to all subscribers that are listening at the moment.
+Subscriptions are kept by the component and restored after the connection is lost or reopened.
+
+Configuration parameters:
+
+  - options:
+    - health_timeout:        interval in milliseconds to check the subscription connection (default: 10000)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    pubsub := NewRedisPubSub();
+    pubsub.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = pubsub.Open(ctx, "123")
+      ...
+
+    err = pubsub.Subscribe(ctx, "123", "events.*", func(ctx context.Context, channel string, envelope *MessageEnvelope) error {
+    	fmt.Println(channel, envelope.GetMessageAsString())
+    	return nil
+    })
+
+    err = pubsub.Publish(ctx, "123", "events.created", NewMessageEnvelope("123", "created", []byte("ABC")))
+*/
+type RedisPubSub struct {
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	healthTimeout int64
+
+	subscriptions map[string]PubSubHandler
+	pubsub        *redis.PubSub
+	done          chan struct{}
+	mtx           sync.Mutex
+}
+
+// NewRedisPubSub method are creates a new instance of the message bus.
+func NewRedisPubSub() *RedisPubSub {
+	return &RedisPubSub{
+		connection:    rconnect.NewRedisConnection(),
+		logger:        *clog.NewCompositeLogger(),
+		healthTimeout: 10000,
+		subscriptions: make(map[string]PubSubHandler),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisPubSub) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+
+	c.healthTimeout = config.GetAsLongWithDefault("options.health_timeout", c.healthTimeout)
+	if c.healthTimeout <= 0 {
+		c.healthTimeout = 10000
+	}
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisPubSub) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisPubSub) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component and restores the subscriptions.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisPubSub) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.pubsub != nil {
+		return nil
+	}
+
+	err := c.connection.Open(ctx, correlationId)
+	if err != nil {
+		return err
+	}
+
+	patterns := make([]string, 0, len(c.subscriptions))
+	for pattern := range c.subscriptions {
+		patterns = append(patterns, pattern)
+	}
+
+	c.pubsub = c.connection.GetClient().PSubscribe(patterns...)
+	c.done = make(chan struct{})
+	go c.listen(correlationId, c.pubsub, c.done)
+
+	return nil
+}
+
+// Close method are closes component and frees used resources.
+// The subscriptions are kept and restored when the component is opened again.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisPubSub) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	pubsub, done := c.pubsub, c.done
+	c.pubsub = nil
+	c.done = nil
+	c.mtx.Unlock()
+
+	if pubsub != nil {
+		pubsub.Close()
+		<-done
+	}
+
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisPubSub) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Publish method are publishes a message to the channel.
+// The message is delivered only to the subscribers that are listening at the moment.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - channel           a channel to publish the message to.
+//  - envelope          a message envelop to be published.
+// Returns: error or nil for success.
+func (c *RedisPubSub) Publish(ctx context.Context, correlationId string, channel string, envelope *MessageEnvelope) error {
+	state, err := c.checkOpened(correlationId)
+	if !state {
+		return err
+	}
+
+	if envelope.MessageId == "" {
+		envelope.MessageId = cdata.IdGenerator.NextLong()
+	}
+	if envelope.CorrelationId == "" {
+		envelope.CorrelationId = correlationId
+	}
+	envelope.SentTime = time.Now().UTC()
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	return c.connection.GetClient().Publish(channel, data).Err()
+}
+
+// Subscribe method are subscribes a handler to the channels matching the pattern.
+// Subscribing to the same pattern again replaces its handler.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - pattern           a glob-style channel pattern, e.g. "events.*".
+//  - handler           a handler to receive published messages.
+// Returns: error or nil for success.
+func (c *RedisPubSub) Subscribe(ctx context.Context, correlationId string, pattern string, handler PubSubHandler) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, subscribed := c.subscriptions[pattern]
+	c.subscriptions[pattern] = handler
+
+	if c.pubsub == nil || subscribed {
+		return nil
+	}
+	return c.pubsub.PSubscribe(pattern)
+}
+
+// Unsubscribe method are removes the handler subscribed to the pattern.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId     (optional) transaction id to trace execution through call chain.
+//  - pattern           a channel pattern used in the subscription.
+// Returns: error or nil for success.
+func (c *RedisPubSub) Unsubscribe(ctx context.Context, correlationId string, pattern string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, subscribed := c.subscriptions[pattern]; !subscribed {
+		return nil
+	}
+	delete(c.subscriptions, pattern)
+
+	if c.pubsub == nil {
+		return nil
+	}
+	return c.pubsub.PUnsubscribe(pattern)
+}
+
+func (c *RedisPubSub) getHandler(pattern string) PubSubHandler {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.subscriptions[pattern]
+}
+
+func (c *RedisPubSub) isListening(pubsub *redis.PubSub) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.pubsub == pubsub
+}
+
+func (c *RedisPubSub) listen(correlationId string, pubsub *redis.PubSub, done chan struct{}) {
+	defer close(done)
+
+	ctx := context.Background()
+	healthTimeout := time.Duration(c.healthTimeout) * time.Millisecond
+	lost := false
+
+	for c.isListening(pubsub) {
+		msg, err := pubsub.ReceiveTimeout(healthTimeout)
+		if err != nil {
+			if !c.isListening(pubsub) {
+				return
+			}
+
+			// Check the idle connection. A failed ping makes the next receive reconnect and resubscribe
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				err = pubsub.Ping()
+				if err == nil {
+					continue
+				}
+			}
+
+			if !lost {
+				c.logger.Error(ctx, correlationId, err, "Lost connection to pub/sub channels")
+				lost = true
+			}
+			time.Sleep(healthTimeout / 10)
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if lost {
+				c.logger.Info(ctx, correlationId, "Restored subscription to %s", msg.Channel)
+				lost = false
+			}
+		case *redis.Message:
+			c.dispatch(ctx, correlationId, msg)
+		}
+	}
+}
+
+func (c *RedisPubSub) dispatch(ctx context.Context, correlationId string, msg *redis.Message) {
+	pattern := msg.Pattern
+	if pattern == "" {
+		pattern = msg.Channel
+	}
+	handler := c.getHandler(pattern)
+	if handler == nil {
+		return
+	}
+
+	envelope := NewEmptyMessageEnvelope()
+	err := json.Unmarshal([]byte(msg.Payload), envelope)
+	if err != nil {
+		c.logger.Warn(ctx, correlationId, "Skipped invalid message at channel %s", msg.Channel)
+		return
+	}
+
+	err = handler(ctx, msg.Channel, envelope)
+	if err != nil {
+		c.logger.Error(ctx, envelope.CorrelationId, err, "Failed to handle the message %s at channel %s",
+			envelope.MessageId, msg.Channel)
+	}
+}
@@ -0,0 +1,8 @@
+package ratelimit
+
+// RateLimitResult contains a decision of the rate limiter.
+type RateLimitResult struct {
+	Allowed    bool  `json:"allowed"`     // True if the requests are allowed
+	Remaining  int64 `json:"remaining"`   // A remaining number of requests in the current interval
+	RetryAfter int64 `json:"retry_after"` // A time in milliseconds after which the requests can be retried
+}
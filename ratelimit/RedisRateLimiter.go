@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+const (
+	// Counts requests in fixed intervals. It is the cheapest algorithm,
+	// but allows bursts of up to twice the limit around interval boundaries.
+	FixedWindowAlgorithm = "fixed_window"
+	// Logs each request and counts them during the last interval. It is precise,
+	// but keeps an entry per request.
+	SlidingWindowAlgorithm = "sliding_window"
+	// Refills a bucket of tokens continuously. It smooths the load while allowing short bursts.
+	TokenBucketAlgorithm = "token_bucket"
+)
+
+/*
+RedisRateLimiter is a distributed rate limiter that is implemented based on Redis in-memory database.
+It limits the number of requests made by the same key during the interval across all service instances.
+Each decision is made atomically by a Lua script.
+
+Configuration parameters:
+
+  - options:
+    - algorithm:             rate limiting algorithm: "fixed_window", "sliding_window" or "token_bucket" (default: "fixed_window")
+    - limit:                 maximum number of requests per interval (default: 100)
+    - interval:              interval in milliseconds (default: 1000)
+
+Connection, credential and client options are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+
+Example:
+	ctx := context.Background()
+
+    limiter := NewRedisRateLimiter();
+    limiter.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+      "options.algorithm", "token_bucket",
+      "options.limit", 10,
+      "options.interval", 1000,
+    ));
+
+    err = limiter.Open(ctx, "123")
+      ...
+
+    result, err := limiter.Allow(ctx, "123", "client1", 1)
+    if err == nil && !result.Allowed {
+    	fmt.Println("Retry after", result.RetryAfter, "ms")
+    }
+*/
+type RedisRateLimiter struct {
+	connection *rconnect.RedisConnection
+
+	algorithm string
+	limit     int64
+	interval  int64
+}
+
+// NewRedisRateLimiter method are creates a new instance of this rate limiter.
+func NewRedisRateLimiter() *RedisRateLimiter {
+	return &RedisRateLimiter{
+		connection: rconnect.NewRedisConnection(),
+		algorithm:  FixedWindowAlgorithm,
+		limit:      100,
+		interval:   1000,
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// 	 - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisRateLimiter) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+
+	c.algorithm = config.GetAsStringWithDefault("options.algorithm", c.algorithm)
+	c.limit = config.GetAsLongWithDefault("options.limit", c.limit)
+	c.interval = config.GetAsLongWithDefault("options.interval", c.interval)
+}
+
+// Sets references to dependent components.
+//	 - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisRateLimiter) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+}
+
+// Checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisRateLimiter) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisRateLimiter) Open(ctx context.Context, correlationId string) error {
+	if c.getScript() == nil {
+		return cerr.NewConfigError(correlationId, "WRONG_ALGORITHM", "Rate limiting algorithm "+c.algorithm+" is not supported").
+			WithDetails("algorithm", c.algorithm)
+	}
+	if c.limit <= 0 || c.interval <= 0 {
+		return cerr.NewConfigError(correlationId, "WRONG_LIMIT", "Rate limit and interval must be positive")
+	}
+
+	return c.connection.Open(ctx, correlationId)
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisRateLimiter) Close(ctx context.Context, correlationId string) error {
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisRateLimiter) checkOpened(correlationId string) (state bool, err error) {
+	if !c.IsOpen() {
+		err = cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisRateLimiter) getScript() *redis.Script {
+	switch c.algorithm {
+	case FixedWindowAlgorithm:
+		return fixedWindowScript
+	case SlidingWindowAlgorithm:
+		return slidingWindowScript
+	case TokenBucketAlgorithm:
+		return tokenBucketScript
+	default:
+		return nil
+	}
+}
+
+// Allow method are takes the requests from the quota of the key.
+// The requests are allowed only when the whole number fits into the remaining quota.
+// The number shall be positive and not greater than the limit, otherwise BadRequestError is returned.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique key of the limited client or resource.
+//   - n                 a number of requests to take.
+// Returns: the rate limiter decision with the remaining quota and the time to retry, or error.
+func (c *RedisRateLimiter) Allow(ctx context.Context, correlationId string, key string, n int64) (*RateLimitResult, error) {
+	if state, err := c.checkOpened(correlationId); !state {
+		return nil, err
+	}
+	// Negative numbers would return requests into the quota and numbers above the limit never fit into it
+	if n <= 0 || n > c.limit {
+		return nil, cerr.NewBadRequestError(correlationId, "WRONG_REQUEST_COUNT",
+			"Number of requests "+strconv.FormatInt(n, 10)+" must be between 1 and the limit "+strconv.FormatInt(c.limit, 10)).
+			WithDetails("key", key).
+			WithDetails("n", n).
+			WithDetails("limit", c.limit)
+	}
+
+	res, err := c.getScript().Run(c.connection.GetClient(), []string{key},
+		c.limit, c.interval, n, cdata.IdGenerator.NextLong()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := res.([]any)
+	if !ok || len(values) < 3 {
+		return nil, cerr.NewInternalError(correlationId, "WRONG_RESULT", "Rate limiter returned unexpected result")
+	}
+
+	return &RateLimitResult{
+		Allowed:    cconv.LongConverter.ToLong(values[0]) == 1,
+		Remaining:  cconv.LongConverter.ToLong(values[1]),
+		RetryAfter: cconv.LongConverter.ToLong(values[2]),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import "github.com/go-redis/redis"
+
+// All rate limiting scripts take the same arguments:
+//   - ARGV[1]  a maximum number of requests per interval
+//   - ARGV[2]  an interval in milliseconds
+//   - ARGV[3]  a number of requests to take
+// and return an array with 1 if the requests are allowed or 0 otherwise,
+// the remaining quota and the time in milliseconds to retry.
+
+// fixedWindowScript counts requests in a counter that is reset at the end of each interval.
+//   - KEYS[1]  a counter key
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if current + n > limit then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl < 0 then
+		ttl = interval
+	end
+	return {0, math.max(limit - current, 0), ttl}
+end
+current = redis.call('INCRBY', KEYS[1], n)
+if current == n then
+	redis.call('PEXPIRE', KEYS[1], interval)
+end
+return {1, limit - current, 0}
+`)
+
+// slidingWindowScript keeps a log of request times and counts the requests made during the last interval.
+//   - KEYS[1]  a log key with requests sorted by their time
+//   - ARGV[4]  a unique id of the call to make unique log entries
+var slidingWindowScript = redis.NewScript(`
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local limit = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - interval)
+local count = redis.call('ZCARD', KEYS[1])
+if count + n > limit then
+	local retry = interval
+	local index = math.min(count + n - limit, count) - 1
+	if index >= 0 then
+		local entry = redis.call('ZRANGE', KEYS[1], index, index, 'WITHSCORES')
+		retry = tonumber(entry[2]) + interval - now
+	end
+	return {0, math.max(limit - count, 0), retry}
+end
+for i = 1, n do
+	redis.call('ZADD', KEYS[1], now, ARGV[4] .. ':' .. i)
+end
+redis.call('PEXPIRE', KEYS[1], interval)
+return {1, limit - count - n, 0}
+`)
+
+// tokenBucketScript keeps a bucket of tokens that is refilled continuously up to the limit during the interval.
+//   - KEYS[1]  a bucket key with the number of tokens and the last refill time
+var tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local limit = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'timestamp')
+local tokens = tonumber(bucket[1]) or limit
+local timestamp = tonumber(bucket[2]) or now
+tokens = math.min(limit, tokens + math.max(now - timestamp, 0) * limit / interval)
+local allowed = 0
+local retry = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+else
+	retry = math.ceil((n - tokens) * interval / limit)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'timestamp', now)
+redis.call('PEXPIRE', KEYS[1], interval)
+return {allowed, math.floor(tokens), retry}
+`)
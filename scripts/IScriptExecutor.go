@@ -0,0 +1,75 @@
+package scripts
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// IScriptExecutor interface to execute raw Redis commands used to load and run scripts.
+// Connections of github.com/gomodule/redigo implement it as is, clients of github.com/go-redis/redis
+// are adapted with NewClientScriptExecutor.
+type IScriptExecutor interface {
+
+	// Do executes a Redis command.
+	//	Parameters:
+	//		- commandName  a name of the command
+	//		- args         arguments of the command
+	//	Returns: a command reply, nil for nil replies, or error.
+	Do(commandName string, args ...any) (reply any, err error)
+}
+
+// processor is implemented by go-redis clients to execute arbitrary commands.
+type processor interface {
+	Process(cmd redis.Cmder) error
+}
+
+type clientScriptExecutor struct {
+	client processor
+}
+
+// NewClientScriptExecutor method are creates an executor that runs commands with a go-redis client.
+// Parameters:
+//   - client    a go-redis client, cluster client or transaction.
+func NewClientScriptExecutor(client redis.UniversalClient) IScriptExecutor {
+	return &clientScriptExecutor{client: client}
+}
+
+func (c *clientScriptExecutor) Do(commandName string, args ...any) (reply any, err error) {
+	// Cluster client sends commands without keys to a random node,
+	// so SCRIPT commands, e.g. SCRIPT LOAD, are sent to all master nodes
+	if cluster, ok := c.client.(*redis.ClusterClient); ok && strings.EqualFold(commandName, "SCRIPT") {
+		return c.doOnMasters(cluster, commandName, args...)
+	}
+
+	cmd := redis.NewCmd(append([]any{commandName}, args...)...)
+	c.client.Process(cmd)
+
+	reply, err = cmd.Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return reply, err
+}
+
+func (c *clientScriptExecutor) doOnMasters(cluster *redis.ClusterClient, commandName string, args ...any) (reply any, err error) {
+	var mtx sync.Mutex
+	err = cluster.ForEachMaster(func(node *redis.Client) error {
+		cmd := redis.NewCmd(append([]any{commandName}, args...)...)
+		node.Process(cmd)
+
+		result, err := cmd.Result()
+		if err != nil {
+			return err
+		}
+		mtx.Lock()
+		reply = result
+		mtx.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
@@ -0,0 +1,269 @@
+package scripts
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisScripts is a registry of named Lua scripts executed in Redis in-memory database.
+Scripts are executed with EVALSHA by their SHA1 digests and automatically loaded again
+when the server responds with NOSCRIPT, e.g. after restart or failover. All registered scripts
+are preloaded when the component is opened, in Redis cluster on all master nodes.
+
+The registry opens its own connection, or runs scripts with a connection of another component
+set by SetExecutor. RedisCache and RedisLock provide such registries with GetScripts.
+
+Configuration parameters are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    scripts := NewRedisScripts();
+    scripts.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+    scripts.Register("incr_max", `
+      local value = redis.call('INCR', KEYS[1])
+      if value > tonumber(ARGV[1]) then
+        redis.call('SET', KEYS[1], ARGV[1])
+        return tonumber(ARGV[1])
+      end
+      return value
+    `)
+
+    err = scripts.Open(ctx, "123")
+      ...
+
+    value, err := scripts.Run(ctx, "123", "incr_max", []string{"counter"}, 10).Int64()
+*/
+type RedisScripts struct {
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	executor    IScriptExecutor
+	ownExecutor bool
+	opened      bool
+
+	scripts map[string]*script
+	mtx     sync.Mutex
+}
+
+type script struct {
+	source string
+	sha    string
+}
+
+// NewRedisScripts method are creates a new instance of the script registry.
+func NewRedisScripts() *RedisScripts {
+	return &RedisScripts{
+		connection: rconnect.NewRedisConnection(),
+		logger:     *clog.NewCompositeLogger(),
+		scripts:    make(map[string]*script),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// Parameters:
+//   - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisScripts) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+}
+
+// SetReferences method are sets references to dependent components.
+// Parameters:
+//   - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisScripts) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// SetExecutor method are sets a connection of another component to run scripts.
+// When the executor is set, the registry does not open its own connection.
+// Parameters:
+//   - executor      an executor of Redis commands or nil to use own connection.
+func (c *RedisScripts) SetExecutor(executor IScriptExecutor) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.executor = executor
+	c.ownExecutor = false
+}
+
+// IsOpen method are checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisScripts) IsOpen() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.opened
+}
+
+// Open method are opens the component and preloads all registered scripts.
+// Parameters:
+//  - ctx context.Context
+// 	- correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisScripts) Open(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.opened {
+		return nil
+	}
+
+	if c.executor == nil {
+		err := c.connection.Open(ctx, correlationId)
+		if err != nil {
+			return err
+		}
+		c.executor = NewClientScriptExecutor(c.connection.GetClient())
+		c.ownExecutor = true
+	}
+
+	for name, script := range c.scripts {
+		if err := c.load(c.executor, correlationId, name, script); err != nil {
+			c.closeExecutor(ctx, correlationId)
+			return err
+		}
+	}
+
+	c.opened = true
+	c.logger.Debug(ctx, correlationId, "Preloaded %d Redis scripts", len(c.scripts))
+	return nil
+}
+
+// Close method are closes component and frees used resources.
+// A connection of another component set by SetExecutor is not closed.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisScripts) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.opened = false
+	return c.closeExecutor(ctx, correlationId)
+}
+
+func (c *RedisScripts) closeExecutor(ctx context.Context, correlationId string) error {
+	if !c.ownExecutor {
+		return nil
+	}
+
+	c.executor = nil
+	c.ownExecutor = false
+	return c.connection.Close(ctx, correlationId)
+}
+
+// Register method are registers a Lua script by its name. A script registered with the same name is replaced.
+// Scripts registered after the component is opened are loaded on their first execution.
+// Parameters:
+//   - name          a unique name of the script.
+//   - source        a Lua source of the script.
+func (c *RedisScripts) Register(name string, source string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	digest := sha1.Sum([]byte(source))
+	c.scripts[name] = &script{source: source, sha: hex.EncodeToString(digest[:])}
+}
+
+// IsRegistered method are checks if a script with the name is registered.
+// Parameters:
+//   - name          a name of the script.
+// Returns: true if the script is registered and false otherwise.
+func (c *RedisScripts) IsRegistered(name string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.scripts[name]
+	return ok
+}
+
+// GetSha method are gets a SHA1 digest of the script used to execute it with EVALSHA.
+// Parameters:
+//   - name          a name of the script.
+// Returns: the digest or an empty string if the script is not registered.
+func (c *RedisScripts) GetSha(name string) string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if script, ok := c.scripts[name]; ok {
+		return script.sha
+	}
+	return ""
+}
+
+func (c *RedisScripts) load(executor IScriptExecutor, correlationId string, name string, script *script) error {
+	_, err := executor.Do("SCRIPT", "LOAD", script.source)
+	if err != nil {
+		return cerr.NewInvalidStateError(correlationId, "SCRIPT_LOAD_FAILED", "Failed to load Redis script "+name).
+			WithDetails("name", name).
+			WithCause(err)
+	}
+	return nil
+}
+
+// Run method are executes a registered script with EVALSHA.
+// When the script is missing on the server it is executed with EVAL that loads it again.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - name              a name of the script.
+//   - keys              keys passed to the script in KEYS.
+//   - args              arguments passed to the script in ARGV.
+// Returns: a script result with typed accessors.
+func (c *RedisScripts) Run(ctx context.Context, correlationId string, name string, keys []string, args ...any) *ScriptResult {
+	c.mtx.Lock()
+	script, ok := c.scripts[name]
+	executor, opened := c.executor, c.opened
+	c.mtx.Unlock()
+
+	if !opened || executor == nil {
+		return NewScriptResult(nil, cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened"))
+	}
+	if !ok {
+		return NewScriptResult(nil, cerr.NewBadRequestError(correlationId, "UNKNOWN_SCRIPT", "Redis script "+name+" is not registered").
+			WithDetails("name", name))
+	}
+
+	cmdArgs := make([]any, 0, len(keys)+len(args)+2)
+	cmdArgs = append(cmdArgs, script.sha, len(keys))
+	for _, key := range keys {
+		cmdArgs = append(cmdArgs, key)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	reply, err := executor.Do("EVALSHA", cmdArgs...)
+	if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT") {
+		c.logger.Debug(ctx, correlationId, "Reloading Redis script %s", name)
+
+		// EVAL runs on the same node as EVALSHA and caches the script there,
+		// while SCRIPT LOAD may be sent to another node of a cluster
+		cmdArgs[0] = script.source
+		reply, err = executor.Do("EVAL", cmdArgs...)
+	}
+
+	return NewScriptResult(reply, err)
+}
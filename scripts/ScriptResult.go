@@ -0,0 +1,162 @@
+package scripts
+
+import (
+	"strconv"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// ScriptResult is a reply of a Lua script with typed accessors.
+// Lua numbers are returned by Redis as integers, so fractional numbers shall be returned as strings.
+type ScriptResult struct {
+	value any
+	err   error
+}
+
+// NewScriptResult method are creates a new script result.
+// Parameters:
+//   - value     a script reply.
+//   - err       an error of the script execution.
+func NewScriptResult(value any, err error) *ScriptResult {
+	return &ScriptResult{value: normalizeReply(value), err: err}
+}
+
+// normalizeReply converts binary strings returned by redigo into strings as go-redis does.
+func normalizeReply(value any) any {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = normalizeReply(item)
+		}
+		return result
+	}
+	return value
+}
+
+// Err method are gets the error of the script execution or nil for success.
+func (r *ScriptResult) Err() error {
+	return r.err
+}
+
+// Value method are gets the raw reply: int64, string, []any or nil.
+func (r *ScriptResult) Value() any {
+	return r.value
+}
+
+// Result method are gets the raw reply and the error of the script execution.
+func (r *ScriptResult) Result() (any, error) {
+	return r.value, r.err
+}
+
+// Int64 method are gets the reply as an integer. Nil reply is converted into 0.
+func (r *ScriptResult) Int64() (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	switch v := r.value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case string:
+		result, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, r.wrongType("integer")
+		}
+		return result, nil
+	}
+	return 0, r.wrongType("integer")
+}
+
+// Int method are gets the reply as an integer. Nil reply is converted into 0.
+func (r *ScriptResult) Int() (int, error) {
+	result, err := r.Int64()
+	return int(result), err
+}
+
+// Bool method are gets the reply as a boolean. Lua true is returned by Redis as 1 and false as nil.
+func (r *ScriptResult) Bool() (bool, error) {
+	result, err := r.Int64()
+	return result != 0, err
+}
+
+// Float64 method are gets the reply as a float number. Nil reply is converted into 0.
+func (r *ScriptResult) Float64() (float64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	switch v := r.value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return float64(v), nil
+	case string:
+		result, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, r.wrongType("float")
+		}
+		return result, nil
+	}
+	return 0, r.wrongType("float")
+}
+
+// String method are gets the reply as a string. Nil reply is converted into an empty string.
+func (r *ScriptResult) String() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	switch v := r.value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	}
+	return "", r.wrongType("string")
+}
+
+// Values method are gets the reply as an array. Nil reply is converted into nil array.
+func (r *ScriptResult) Values() ([]any, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	switch v := r.value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		return v, nil
+	}
+	return nil, r.wrongType("array")
+}
+
+// Strings method are gets the reply as an array of strings. Nil elements are converted into empty strings.
+func (r *ScriptResult) Strings() ([]string, error) {
+	values, err := r.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		if result[i], err = NewScriptResult(value, nil).String(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *ScriptResult) wrongType(expected string) error {
+	return cerr.NewUnknownError("", "WRONG_REPLY_TYPE", "Script reply cannot be converted into "+expected).
+		WithDetails("reply", r.value)
+}
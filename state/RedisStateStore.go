@@ -0,0 +1,278 @@
+package state
+
+import (
+	"context"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cconv "github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cref "github.com/pip-services3-gox/pip-services3-commons-gox/refer"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	cstate "github.com/pip-services3-gox/pip-services3-components-gox/state"
+	rconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+)
+
+/*
+RedisStateStore is a state store that keeps states in Redis in-memory database.
+Unlike cache the states are durable and never expire.
+The store connects to Redis with RedisConnection, the same way as RedisCache does.
+
+Configuration parameters are the same as in RedisConnection.
+
+References:
+
+- *:discovery:*:*:1.0        (optional) IDiscovery services to resolve connection
+- *:credential-store:*:*:1.0 (optional) Credential stores to resolve credential
+- *:logger:*:*:1.0           (optional) ILogger components to pass log messages
+
+Example:
+	ctx := context.Background()
+
+    store := NewRedisStateStore[MyState]();
+    store.Configure(ctx, cconf.NewConfigParamsFromTuples(
+      "host", "localhost",
+      "port", 6379,
+    ));
+
+    err = store.Open(ctx, "123")
+      ...
+
+    store.Save(ctx, "123", "key1", MyState{Value: "ABC"})
+
+    value := store.Load(ctx, "123", "key1")
+    fmt.Println(value.Value)     // Result: "ABC"
+*/
+type RedisStateStore[T any] struct {
+	connection *rconnect.RedisConnection
+	logger     clog.CompositeLogger
+
+	convertor cconv.IJSONEngine[T]
+}
+
+// NewRedisStateStore method are creates a new instance of this state store.
+func NewRedisStateStore[T any]() *RedisStateStore[T] {
+	return &RedisStateStore[T]{
+		connection: rconnect.NewRedisConnection(),
+		logger:     *clog.NewCompositeLogger(),
+		convertor:  cconv.NewDefaultCustomTypeJsonConvertor[T](),
+	}
+}
+
+// Configure method are configures component by passing configuration parameters.
+// 	 - ctx context.Context
+//   - config    configuration parameters to be set.
+func (c *RedisStateStore[T]) Configure(ctx context.Context, config *cconf.ConfigParams) {
+	c.connection.Configure(ctx, config)
+	c.logger.Configure(ctx, config)
+}
+
+// Sets references to dependent components.
+//	 - ctx context.Context
+//   - references 	references to locate the component dependencies.
+func (c *RedisStateStore[T]) SetReferences(ctx context.Context, references cref.IReferences) {
+	c.connection.SetReferences(ctx, references)
+	c.logger.SetReferences(ctx, references)
+}
+
+// Checks if the component is opened.
+// Returns true if the component has been opened and false otherwise.
+func (c *RedisStateStore[T]) IsOpen() bool {
+	return c.connection.IsOpen()
+}
+
+// Open method are opens the component.
+// Parameters:
+//  - ctx context.Context
+//  - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns: error or nil no errors occured.
+func (c *RedisStateStore[T]) Open(ctx context.Context, correlationId string) error {
+	return c.connection.Open(ctx, correlationId)
+}
+
+// Close method are closes component and frees used resources.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+// Retruns: error or nil no errors occured.
+func (c *RedisStateStore[T]) Close(ctx context.Context, correlationId string) error {
+	return c.connection.Close(ctx, correlationId)
+}
+
+func (c *RedisStateStore[T]) checkOpened(ctx context.Context, correlationId string) bool {
+	if !c.IsOpen() {
+		err := cerr.NewInvalidStateError(correlationId, "NOT_OPENED", "Connection is not opened")
+		c.logger.Error(ctx, correlationId, err, "Connection is not opened")
+		return false
+	}
+	return true
+}
+
+// Load method are loads state from the store using its key.
+// If value is missing in the store it returns nil.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique state key.
+// Returns: the state value or nil if value wasn't found.
+func (c *RedisStateStore[T]) Load(ctx context.Context, correlationId string, key string) T {
+	var defaultValue T
+
+	if len(key) == 0 {
+		panic(cerr.NewError("Key cannot be empty"))
+	}
+	if !c.checkOpened(ctx, correlationId) {
+		return defaultValue
+	}
+
+	item, err := c.connection.GetClient().Get(key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			c.logger.Error(ctx, correlationId, err, "Failed to load state %s", key)
+		}
+		return defaultValue
+	}
+
+	return c.fromJson(ctx, correlationId, key, item)
+}
+
+// LoadBulk method are loads an array of states from the store using their keys.
+// The states are read in a single pipeline.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - keys              unique state keys.
+// Returns: an array with state values and their corresponding keys.
+func (c *RedisStateStore[T]) LoadBulk(ctx context.Context, correlationId string, keys []string) []cstate.StateValue[T] {
+	result := make([]cstate.StateValue[T], 0)
+	if len(keys) == 0 || !c.checkOpened(ctx, correlationId) {
+		return result
+	}
+
+	pipe := c.connection.GetClient().Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(key)
+	}
+
+	_, err := pipe.Exec()
+	if err != nil && err != redis.Nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to load states")
+		return result
+	}
+
+	for i, cmd := range cmds {
+		item, err := cmd.Result()
+		if err != nil {
+			continue
+		}
+		result = append(result, cstate.StateValue[T]{Key: keys[i], Value: c.fromJson(ctx, correlationId, keys[i], item)})
+	}
+	return result
+}
+
+// Save method are saves state into the store.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique state key.
+//   - value             a state value.
+// Returns: the state that was stored in the store.
+func (c *RedisStateStore[T]) Save(ctx context.Context, correlationId string, key string, value T) T {
+	var defaultValue T
+
+	if len(key) == 0 {
+		panic(cerr.NewError("Key cannot be empty"))
+	}
+	if !c.checkOpened(ctx, correlationId) {
+		return defaultValue
+	}
+
+	item, err := c.convertor.ToJson(value)
+	if err != nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to serialize state %s", key)
+		return defaultValue
+	}
+
+	if err = c.connection.GetClient().Set(key, item, 0).Err(); err != nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to save state %s", key)
+		return defaultValue
+	}
+	return value
+}
+
+// SaveBulk method are saves an array of states into the store in a single pipeline.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - values            state values with their corresponding keys.
+// Returns: the states that were stored in the store.
+func (c *RedisStateStore[T]) SaveBulk(ctx context.Context, correlationId string, values []cstate.StateValue[T]) []cstate.StateValue[T] {
+	result := make([]cstate.StateValue[T], 0)
+	if len(values) == 0 || !c.checkOpened(ctx, correlationId) {
+		return result
+	}
+
+	pipe := c.connection.GetClient().Pipeline()
+	for _, value := range values {
+		if len(value.Key) == 0 {
+			panic(cerr.NewError("Key cannot be empty"))
+		}
+
+		item, err := c.convertor.ToJson(value.Value)
+		if err != nil {
+			c.logger.Error(ctx, correlationId, err, "Failed to serialize state %s", value.Key)
+			continue
+		}
+		pipe.Set(value.Key, item, 0)
+		result = append(result, value)
+	}
+
+	if _, err := pipe.Exec(); err != nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to save states")
+		return make([]cstate.StateValue[T], 0)
+	}
+	return result
+}
+
+// Delete method are deletes a state from the store by its key.
+// Parameters:
+//   - ctx context.Context
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - key               a unique value key.
+// Returns: the state that was deleted in the store.
+func (c *RedisStateStore[T]) Delete(ctx context.Context, correlationId string, key string) T {
+	var defaultValue T
+
+	if len(key) == 0 {
+		panic(cerr.NewError("Key cannot be empty"))
+	}
+	if !c.checkOpened(ctx, correlationId) {
+		return defaultValue
+	}
+
+	// Read and remove the state in one transaction
+	pipe := c.connection.GetClient().TxPipeline()
+	get := pipe.Get(key)
+	pipe.Del(key)
+
+	_, err := pipe.Exec()
+	if err != nil && err != redis.Nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to delete state %s", key)
+		return defaultValue
+	}
+
+	item, err := get.Result()
+	if err != nil {
+		return defaultValue
+	}
+	return c.fromJson(ctx, correlationId, key, item)
+}
+
+func (c *RedisStateStore[T]) fromJson(ctx context.Context, correlationId string, key string, item string) T {
+	value, err := c.convertor.FromJson(item)
+	if err != nil {
+		c.logger.Error(ctx, correlationId, err, "Failed to deserialize state %s", key)
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package test_auth
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cauth "github.com/pip-services3-gox/pip-services3-components-gox/auth"
+	redisauth "github.com/pip-services3-gox/pip-services3-redis-gox/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCredentialStore(t *testing.T, encryptionKey string, previousKeys string) *redisauth.RedisCredentialStore {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	store := redisauth.NewRedisCredentialStore()
+	store.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.encryption_key", encryptionKey,
+		"options.previous_keys", previousKeys,
+		"options.max_versions", 2,
+	))
+	err := store.Open(ctx, "")
+	assert.Nil(t, err)
+	return store
+}
+
+func TestRedisCredentialStore(t *testing.T) {
+	ctx := context.Background()
+
+	store := newCredentialStore(t, "secret1", "")
+	defer store.Close(ctx, "")
+
+	key := "test_credentials_" + cdata.IdGenerator.NextShort()
+	defer store.Store(ctx, "", key, nil)
+
+	_, err := store.Lookup(ctx, "", key)
+	assert.NotNil(t, err)
+
+	for _, password := range []string{"pass1", "pass2", "pass3"} {
+		err = store.Store(ctx, "", key, cauth.NewCredentialParamsFromTuples(
+			"username", "user1",
+			"password", password,
+		))
+		assert.Nil(t, err)
+	}
+
+	credential, err := store.Lookup(ctx, "", key)
+	assert.Nil(t, err)
+	assert.Equal(t, "user1", credential.Username())
+	assert.Equal(t, "pass3", credential.Password())
+
+	// Only the last versions are kept
+	versions, err := store.GetVersions(ctx, "", key)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2, 3}, versions)
+
+	credential, err = store.LookupVersion(ctx, "", key, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "pass2", credential.Password())
+
+	_, err = store.LookupVersion(ctx, "", key, 1)
+	assert.NotNil(t, err)
+
+	// Credentials can't be decrypted with another key
+	otherStore := newCredentialStore(t, "secret2", "")
+	_, err = otherStore.Lookup(ctx, "", key)
+	assert.NotNil(t, err)
+	otherStore.Close(ctx, "")
+
+	// Rotate the encryption key
+	rotatedStore := newCredentialStore(t, "secret2", "secret1")
+	defer rotatedStore.Close(ctx, "")
+
+	err = rotatedStore.Rotate(ctx, "", key)
+	assert.Nil(t, err)
+
+	credential, err = rotatedStore.LookupVersion(ctx, "", key, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "pass2", credential.Password())
+
+	_, err = store.Lookup(ctx, "", key)
+	assert.NotNil(t, err)
+}
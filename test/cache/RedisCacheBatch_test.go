@@ -0,0 +1,124 @@
+package test_cache
+
+import (
+	"context"
+	"testing"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCacheTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	cache := rediscache.NewRedisCache[string]()
+	cache.Configure(ctx, getCollectionConfig())
+	cache.Open(ctx, "")
+	defer cache.Close(ctx, "")
+
+	cache.Remove(ctx, "", "{test:tx}:counter")
+	cache.Store(ctx, "", "{test:tx}:draft", "draft", 5000)
+
+	tx := cache.Transaction()
+	stored := tx.Store("{test:tx}:value", "ABC", 5000)
+	count := tx.Increment("{test:tx}:counter", 2)
+	removed := tx.Remove("{test:tx}:draft")
+	assert.Equal(t, 3, tx.Len())
+
+	err := tx.Execute(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, tx.Len())
+
+	assert.Nil(t, stored.Err())
+	assert.Equal(t, "ABC", stored.Value())
+	assert.Equal(t, int64(2), count.Value())
+	assert.True(t, removed.Value())
+
+	value, err := cache.Retrieve(ctx, "", "{test:tx}:value")
+	assert.Nil(t, err)
+	assert.Equal(t, "ABC", value)
+	assert.False(t, cache.Contains(ctx, "", "{test:tx}:draft"))
+
+	batch := cache.Batch()
+	batch.Remove("{test:tx}:value")
+	batch.Remove("{test:tx}:counter")
+	removed = batch.Remove("{test:tx}:draft")
+	err = batch.Execute(ctx, "")
+	assert.Nil(t, err)
+	assert.False(t, removed.Value())
+}
+
+func TestRedisCacheTransactionFailedOperation(t *testing.T) {
+	ctx := context.Background()
+
+	cache := rediscache.NewRedisCache[string]()
+	cache.Configure(ctx, getCollectionConfig())
+	cache.Open(ctx, "")
+	defer cache.Close(ctx, "")
+
+	cache.Store(ctx, "", "{test:tx_fail}:text", "ABC", 5000)
+	cache.Remove(ctx, "", "{test:tx_fail}:value")
+	defer cache.Remove(ctx, "", "{test:tx_fail}:text")
+	defer cache.Remove(ctx, "", "{test:tx_fail}:value")
+
+	tx := cache.Transaction()
+	count := tx.Increment("{test:tx_fail}:text", 1)
+	stored := tx.Store("{test:tx_fail}:value", "XYZ", 5000)
+
+	err := tx.Execute(ctx, "")
+	assert.NotNil(t, err)
+	assert.NotNil(t, count.Err())
+	assert.Equal(t, int64(0), count.Value())
+
+	// Other operations of the transaction are not rolled back
+	assert.Nil(t, stored.Err())
+	value, err := cache.Retrieve(ctx, "", "{test:tx_fail}:value")
+	assert.Nil(t, err)
+	assert.Equal(t, "XYZ", value)
+}
+
+func TestRedisCacheTransactionCrossSlot(t *testing.T) {
+	ctx := context.Background()
+
+	// Keys are checked before the transaction is sent, so the cache is not opened
+	config := getCollectionConfig()
+	config.SetAsObject("options.cluster", true)
+
+	cache := rediscache.NewRedisCache[string]()
+	cache.Configure(ctx, config)
+
+	tx := cache.Transaction()
+	tx.Store("foo", "A", 5000)
+	tx.Remove("bar")
+	err := tx.Execute(ctx, "")
+	assert.NotNil(t, err)
+	appErr := err.(*cerr.ApplicationError)
+	assert.Equal(t, "CROSS_SLOT", appErr.Code)
+	assert.Equal(t, "12182", appErr.Details["slot1"])
+	assert.Equal(t, "5061", appErr.Details["slot2"])
+
+	// Only hash tags are hashed
+	tx.Store("{foo}:value", "A", 5000)
+	tx.Remove("{bar}:value")
+	err = tx.Execute(ctx, "")
+	assert.NotNil(t, err)
+	appErr = err.(*cerr.ApplicationError)
+	assert.Equal(t, "12182", appErr.Details["slot1"])
+	assert.Equal(t, "5061", appErr.Details["slot2"])
+
+	// Keys with the same hash tag pass the check
+	tx.Store("{user1000}.following", "A", 5000)
+	tx.Increment("{user1000}.followers", 1)
+	err = tx.Execute(ctx, "")
+	assert.NotNil(t, err)
+	assert.Equal(t, "NOT_OPENED", err.(*cerr.ApplicationError).Code)
+
+	// Batches are not checked
+	batch := cache.Batch()
+	batch.Store("foo", "A", 5000)
+	batch.Remove("bar")
+	err = batch.Execute(ctx, "")
+	assert.NotNil(t, err)
+	assert.Equal(t, "NOT_OPENED", err.(*cerr.ApplicationError).Code)
+}
@@ -0,0 +1,114 @@
+package test_cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type hashDummy struct {
+	Id      string   `json:"id"`
+	Name    string   `redis:"name"`
+	Count   int      `json:"count"`
+	Tags    []string `json:"tags"`
+	Skipped string   `json:"-"`
+}
+
+func TestRedisCacheHashMode(t *testing.T) {
+	ctx := context.Background()
+
+	config := getCollectionConfig()
+	config.SetAsObject("options.hash_mode", true)
+
+	cache := rediscache.NewRedisCache[hashDummy]()
+	cache.Configure(ctx, config)
+	cache.Open(ctx, "")
+	defer cache.Close(ctx, "")
+
+	dummy := hashDummy{Id: "1", Name: "Dummy", Count: 5, Tags: []string{"a", "b"}, Skipped: "X"}
+	_, err := cache.Store(ctx, "", "test:hash", dummy, 5000)
+	assert.Nil(t, err)
+
+	value, err := cache.Retrieve(ctx, "", "test:hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "Dummy", value.Name)
+	assert.Equal(t, 5, value.Count)
+	assert.Equal(t, []string{"a", "b"}, value.Tags)
+	assert.Equal(t, "", value.Skipped)
+
+	updated, err := cache.UpdateFields(ctx, "", "test:hash", map[string]any{"count": 7})
+	assert.Nil(t, err)
+	assert.True(t, updated)
+
+	value, err = cache.RetrieveFields(ctx, "", "test:hash", "count")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, value.Count)
+	assert.Equal(t, "", value.Name)
+
+	_, err = cache.UpdateFields(ctx, "", "test:hash", map[string]any{"unknown": 1})
+	assert.NotNil(t, err)
+
+	updated, err = cache.UpdateFields(ctx, "", "test:missing", map[string]any{"count": 1})
+	assert.Nil(t, err)
+	assert.False(t, updated)
+
+	_, err = cache.Store(ctx, "", "test:hash", dummy, 500)
+	assert.Nil(t, err)
+
+	time.Sleep(time.Second)
+
+	value, err = cache.Retrieve(ctx, "", "test:hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "", value.Id)
+}
+
+func TestRedisCacheHashUpdateFieldTypes(t *testing.T) {
+	ctx := context.Background()
+
+	config := getCollectionConfig()
+	config.SetAsObject("options.hash_mode", true)
+
+	cache := rediscache.NewRedisCache[hashDummy]()
+	cache.Configure(ctx, config)
+	cache.Open(ctx, "")
+	defer cache.Close(ctx, "")
+
+	dummy := hashDummy{Id: "1", Name: "Dummy", Count: 5, Tags: []string{"a"}}
+	_, err := cache.Store(ctx, "", "test:hash_types", dummy, 5000)
+	assert.Nil(t, err)
+	defer cache.Remove(ctx, "", "test:hash_types")
+
+	// Values of compatible types are converted into the field types
+	updated, err := cache.UpdateFields(ctx, "", "test:hash_types", map[string]any{
+		"count": float64(9),
+		"tags":  []any{"b", "c"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, updated)
+
+	value, err := cache.Retrieve(ctx, "", "test:hash_types")
+	assert.Nil(t, err)
+	assert.Equal(t, 9, value.Count)
+	assert.Equal(t, []string{"b", "c"}, value.Tags)
+
+	// Values of wrong types are rejected and the stored value is not broken
+	for _, fields := range []map[string]any{
+		{"count": "abc"},
+		{"count": 1.5},
+		{"name": 123},
+		{"tags": "a"},
+	} {
+		_, err = cache.UpdateFields(ctx, "", "test:hash_types", fields)
+		assert.NotNil(t, err)
+		assert.Equal(t, "WRONG_FIELD_TYPE", err.(*cerr.ApplicationError).Code)
+	}
+
+	value, err = cache.Retrieve(ctx, "", "test:hash_types")
+	assert.Nil(t, err)
+	assert.Equal(t, 9, value.Count)
+	assert.Equal(t, "Dummy", value.Name)
+}
@@ -0,0 +1,183 @@
+package test_cache
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCollectionConfig() *cconf.ConfigParams {
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	return cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.ttl", 60000,
+	)
+}
+
+func TestRedisMap(t *testing.T) {
+	ctx := context.Background()
+
+	m := rediscache.NewRedisMap[string, int]("test:map")
+	m.Configure(ctx, getCollectionConfig())
+	m.Open(ctx, "")
+	defer m.Close(ctx, "")
+	m.Clear(ctx, "")
+
+	err := m.PutAll(ctx, "", map[string]int{"a": 1, "b": 2})
+	assert.Nil(t, err)
+	err = m.Put(ctx, "", "c", 3)
+	assert.Nil(t, err)
+
+	value, ok, err := m.Get(ctx, "", "b")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	_, ok, err = m.Get(ctx, "", "d")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	sum := 0
+	err = m.Range(ctx, "", func(key string, value int) bool {
+		sum += value
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, sum)
+
+	err = m.Delete(ctx, "", "a", "b")
+	assert.Nil(t, err)
+
+	count, err := m.Len(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	m.Clear(ctx, "")
+}
+
+func TestRedisList(t *testing.T) {
+	ctx := context.Background()
+
+	l := rediscache.NewRedisList[string]("test:list")
+	l.Configure(ctx, getCollectionConfig())
+	l.Open(ctx, "")
+	defer l.Close(ctx, "")
+	l.Clear(ctx, "")
+
+	err := l.Push(ctx, "", "b", "c")
+	assert.Nil(t, err)
+	err = l.PushFront(ctx, "", "a")
+	assert.Nil(t, err)
+
+	values, err := l.Slice(ctx, "", 0, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	err = l.Set(ctx, "", 1, "B")
+	assert.Nil(t, err)
+	value, ok, err := l.Get(ctx, "", 1)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "B", value)
+
+	value, ok, err = l.Pop(ctx, "")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "c", value)
+
+	value, ok, err = l.PopFront(ctx, "")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+
+	count, err := l.Len(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	l.Clear(ctx, "")
+}
+
+func TestRedisListPopRefreshesTtl(t *testing.T) {
+	ctx := context.Background()
+
+	l := rediscache.NewRedisList[string]("test:list_ttl")
+	l.Configure(ctx, getCollectionConfig().Override(
+		cconf.NewConfigParamsFromTuples("options.ttl", 1000),
+	))
+	l.Open(ctx, "")
+	defer l.Close(ctx, "")
+	l.Clear(ctx, "")
+
+	err := l.Push(ctx, "", "a", "b", "c")
+	assert.Nil(t, err)
+
+	// Every pop refreshes the expiration timeout, so the list outlives the first timeout
+	for _, expected := range []string{"c", "b"} {
+		time.Sleep(600 * time.Millisecond)
+		value, ok, err := l.Pop(ctx, "")
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, value)
+	}
+
+	count, err := l.Len(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	l.Clear(ctx, "")
+}
+
+func TestRedisSet(t *testing.T) {
+	ctx := context.Background()
+
+	s := rediscache.NewRedisSet[string]("test:set")
+	s.Configure(ctx, getCollectionConfig())
+	s.Open(ctx, "")
+	defer s.Close(ctx, "")
+	s.Clear(ctx, "")
+
+	err := s.Add(ctx, "", "red", "green", "red")
+	assert.Nil(t, err)
+
+	count, err := s.Len(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	members, err := s.Members(ctx, "")
+	assert.Nil(t, err)
+	sort.Strings(members)
+	assert.Equal(t, []string{"green", "red"}, members)
+
+	ok, err := s.Contains(ctx, "", "green")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = s.Remove(ctx, "", "green")
+	assert.Nil(t, err)
+
+	values := make([]string, 0)
+	err = s.Range(ctx, "", func(value string) bool {
+		values = append(values, value)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"red"}, values)
+
+	s.Clear(ctx, "")
+}
@@ -0,0 +1,133 @@
+package test_config
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	crun "github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	redisconfig "github.com/pip-services3-gox/pip-services3-redis-gox/config"
+	redisconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+type testListener struct {
+	events chan string
+}
+
+func (c *testListener) Notify(ctx context.Context, correlationId string, args *crun.Parameters) {
+	c.events <- args.GetAsString("event")
+}
+
+func getConfig(key string) *cconf.ConfigParams {
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	return cconf.NewConfigParamsFromTuples(
+		"key", key,
+		"connection.host", host,
+		"connection.port", port,
+		"options.configure_notifications", true,
+	)
+}
+
+func TestRedisConfigReader(t *testing.T) {
+	ctx := context.Background()
+
+	key := "test_config_" + cdata.IdGenerator.NextShort()
+	config := getConfig(key)
+
+	connection := redisconnect.NewRedisConnection()
+	connection.Configure(ctx, config)
+	err := connection.Open(ctx, "")
+	assert.Nil(t, err)
+	defer connection.Close(ctx, "")
+	defer connection.GetClient().Del(key)
+
+	reader := redisconfig.NewRedisConfigReader()
+	reader.Configure(ctx, config)
+	err = reader.Open(ctx, "")
+	assert.Nil(t, err)
+	defer reader.Close(ctx, "")
+
+	listener := &testListener{events: make(chan string, 10)}
+	reader.AddChangeListener(ctx, listener)
+
+	_, err = reader.ReadConfig(ctx, "", nil)
+	assert.NotNil(t, err)
+
+	// Read configuration from a hash
+	err = connection.GetClient().HMSet(key, map[string]any{
+		"connection.host": "{{HOST}}",
+		"connection.port": "8080",
+	}).Err()
+	assert.Nil(t, err)
+
+	select {
+	case event := <-listener.events:
+		assert.Equal(t, "hset", event)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Change was not notified")
+	}
+
+	result, err := reader.ReadConfig(ctx, "", cconf.NewConfigParamsFromTuples("HOST", "localhost"))
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", result.GetAsString("connection.host"))
+	assert.Equal(t, 8080, result.GetAsInteger("connection.port"))
+
+	// Read configuration from a JSON key
+	reader.RemoveChangeListener(ctx, listener)
+	err = connection.GetClient().Del(key).Err()
+	assert.Nil(t, err)
+	err = connection.GetClient().Set(key, `{"connection": {"host": "{{HOST}}", "port": 8081}}`, 0).Err()
+	assert.Nil(t, err)
+
+	result, err = reader.ReadConfig(ctx, "", cconf.NewConfigParamsFromTuples("HOST", "localhost"))
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", result.GetAsString("connection.host"))
+	assert.Equal(t, 8081, result.GetAsInteger("connection.port"))
+}
+
+func TestRedisConfigReaderKeepsNotificationFlags(t *testing.T) {
+	ctx := context.Background()
+
+	config := getConfig("test_config_" + cdata.IdGenerator.NextShort())
+
+	connection := redisconnect.NewRedisConnection()
+	connection.Configure(ctx, config)
+	err := connection.Open(ctx, "")
+	assert.Nil(t, err)
+	defer connection.Close(ctx, "")
+
+	client := connection.GetClient()
+	values, err := client.ConfigGet("notify-keyspace-events").Result()
+	assert.Nil(t, err)
+	defer client.ConfigSet("notify-keyspace-events", values[1].(string))
+
+	// Keyevent notifications used by another client
+	err = client.ConfigSet("notify-keyspace-events", "Ex").Err()
+	assert.Nil(t, err)
+
+	reader := redisconfig.NewRedisConfigReader()
+	reader.Configure(ctx, config)
+	err = reader.Open(ctx, "")
+	assert.Nil(t, err)
+	defer reader.Close(ctx, "")
+
+	values, err = client.ConfigGet("notify-keyspace-events").Result()
+	assert.Nil(t, err)
+	flags := values[1].(string)
+	for _, flag := range []string{"E", "x", "K", "g", "h", "$"} {
+		assert.Contains(t, flags, flag)
+	}
+}
@@ -0,0 +1,84 @@
+package test_connect
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	ccon "github.com/pip-services3-gox/pip-services3-components-gox/connect"
+	redisconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDiscovery(t *testing.T) *redisconnect.RedisDiscovery {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	discovery := redisconnect.NewRedisDiscovery()
+	discovery.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.heartbeat_ttl", 500,
+	))
+	err := discovery.Open(ctx, "")
+	assert.Nil(t, err)
+	return discovery
+}
+
+func TestRedisDiscovery(t *testing.T) {
+	ctx := context.Background()
+
+	discovery1 := newDiscovery(t)
+	defer discovery1.Close(ctx, "")
+	discovery2 := newDiscovery(t)
+
+	key := "test_service_" + cdata.IdGenerator.NextShort()
+
+	_, err := discovery1.Register("", key, ccon.NewConnectionParamsFromTuples(
+		"protocol", "http", "host", "10.1.1.100", "port", 8080,
+	))
+	assert.Nil(t, err)
+	_, err = discovery2.Register("", key, ccon.NewConnectionParamsFromTuples(
+		"protocol", "http", "host", "10.1.1.101", "port", 8080,
+	))
+	assert.Nil(t, err)
+
+	connections, err := discovery1.ResolveAll("", key)
+	assert.Nil(t, err)
+	assert.Len(t, connections, 2)
+
+	connection, err := discovery1.ResolveOne("", key)
+	assert.Nil(t, err)
+	assert.NotNil(t, connection)
+	assert.Equal(t, 8080, connection.Port())
+
+	// Registrations of the closed instance expire without heartbeats
+	err = discovery2.Close(ctx, "")
+	assert.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	connections, err = discovery1.ResolveAll("", key)
+	assert.Nil(t, err)
+	assert.Len(t, connections, 1)
+	assert.Equal(t, "10.1.1.100", connections[0].Host())
+
+	err = discovery1.Unregister("", key, connections[0])
+	assert.Nil(t, err)
+
+	connection, err = discovery1.ResolveOne("", key)
+	assert.Nil(t, err)
+	assert.Nil(t, connection)
+}
@@ -0,0 +1,118 @@
+package test_count
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	ccount "github.com/pip-services3-gox/pip-services3-components-gox/count"
+	rediscount "github.com/pip-services3-gox/pip-services3-redis-gox/count"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCounters(t *testing.T, prefix string, options ...any) *rediscount.RedisCounters {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	counters := rediscount.NewRedisCounters()
+	config := cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.prefix", prefix,
+		"options.window", 60000,
+		"options.retention", 120000,
+	)
+	counters.Configure(ctx, config.Override(cconf.NewConfigParamsFromTuples(options...)))
+	err := counters.Open(ctx, "")
+	assert.Nil(t, err)
+	return counters
+}
+
+func findCounter(counters []ccount.Counter, name string) *ccount.Counter {
+	for _, counter := range counters {
+		if counter.Name == name {
+			return &counter
+		}
+	}
+	return nil
+}
+
+func TestRedisCounters(t *testing.T) {
+	ctx := context.Background()
+
+	prefix := "test_counters_" + cdata.IdGenerator.NextShort()
+	counters1 := newCounters(t, prefix)
+	defer counters1.Close(ctx, "")
+	counters2 := newCounters(t, prefix)
+	defer counters2.Close(ctx, "")
+
+	counters1.Increment(ctx, "test.calls", 2)
+	counters2.Increment(ctx, "test.calls", 3)
+	counters1.Stats(ctx, "test.stats", 1)
+	counters2.Stats(ctx, "test.stats", 5)
+	counters2.Last(ctx, "test.last", 7)
+
+	assert.Nil(t, counters1.Dump(ctx))
+	assert.Nil(t, counters2.Dump(ctx))
+
+	// Only increments are flushed again
+	counters1.Increment(ctx, "test.calls", 1)
+	assert.Nil(t, counters1.Dump(ctx))
+
+	snapshot, err := counters1.ReadSnapshot(ctx, "", time.Now().Add(-time.Minute), time.Now())
+	assert.Nil(t, err)
+
+	calls := findCounter(snapshot, "test.calls")
+	assert.NotNil(t, calls)
+	assert.Equal(t, int64(6), calls.Count)
+
+	stats := findCounter(snapshot, "test.stats")
+	assert.NotNil(t, stats)
+	assert.Equal(t, int64(2), stats.Count)
+	assert.Equal(t, float64(1), stats.Min)
+	assert.Equal(t, float64(5), stats.Max)
+	assert.Equal(t, float64(3), stats.Average)
+
+	last := findCounter(snapshot, "test.last")
+	assert.NotNil(t, last)
+	assert.Equal(t, float64(7), last.Last)
+}
+
+func TestRedisCountersWindowMinMax(t *testing.T) {
+	ctx := context.Background()
+
+	prefix := "test_counters_" + cdata.IdGenerator.NextShort()
+	counters := newCounters(t, prefix, "options.window", 500)
+	defer counters.Close(ctx, "")
+
+	counters.Stats(ctx, "test.stats", 10)
+	assert.Nil(t, counters.Dump(ctx))
+
+	// The second flush goes into the next window with values that do not overlap the first ones
+	time.Sleep(600 * time.Millisecond)
+	from := time.Now()
+	counters.Stats(ctx, "test.stats", 100)
+	counters.Stats(ctx, "test.stats", 200)
+	assert.Nil(t, counters.Dump(ctx))
+
+	snapshot, err := counters.ReadSnapshot(ctx, "", from, time.Now())
+	assert.Nil(t, err)
+
+	stats := findCounter(snapshot, "test.stats")
+	assert.NotNil(t, stats)
+	assert.Equal(t, int64(2), stats.Count)
+	assert.Equal(t, float64(100), stats.Min)
+	assert.Equal(t, float64(200), stats.Max)
+}
@@ -0,0 +1,62 @@
+package test_fixture
+
+import (
+	"context"
+	"testing"
+
+	cstate "github.com/pip-services3-gox/pip-services3-components-gox/state"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	STATE_KEY1 string = "state_key1"
+	STATE_KEY2 string = "state_key2"
+	STATE_KEY3 string = "state_key3"
+
+	STATE_VALUE1 string = "state_value1"
+	STATE_VALUE2 string = "state_value2"
+	STATE_VALUE3 string = "state_value3"
+)
+
+type StateStoreFixture struct {
+	store cstate.IStateStore[string]
+}
+
+func NewStateStoreFixture(store cstate.IStateStore[string]) *StateStoreFixture {
+	c := StateStoreFixture{}
+	c.store = store
+	return &c
+}
+
+func (c *StateStoreFixture) TestSaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+
+	value := c.store.Save(ctx, "", STATE_KEY1, STATE_VALUE1)
+	assert.Equal(t, STATE_VALUE1, value)
+
+	value = c.store.Save(ctx, "", STATE_KEY2, STATE_VALUE2)
+	assert.Equal(t, STATE_VALUE2, value)
+
+	value = c.store.Load(ctx, "", STATE_KEY1)
+	assert.Equal(t, STATE_VALUE1, value)
+
+	values := c.store.LoadBulk(ctx, "", []string{STATE_KEY2, "missing_state_key"})
+	assert.Len(t, values, 1)
+	assert.Equal(t, STATE_KEY2, values[0].Key)
+	assert.Equal(t, STATE_VALUE2, values[0].Value)
+
+	c.store.Delete(ctx, "", STATE_KEY1)
+	c.store.Delete(ctx, "", STATE_KEY2)
+}
+
+func (c *StateStoreFixture) TestDelete(t *testing.T) {
+	ctx := context.Background()
+
+	c.store.Save(ctx, "", STATE_KEY3, STATE_VALUE3)
+
+	value := c.store.Delete(ctx, "", STATE_KEY3)
+	assert.Equal(t, STATE_VALUE3, value)
+
+	value = c.store.Load(ctx, "", STATE_KEY3)
+	assert.Equal(t, "", value)
+}
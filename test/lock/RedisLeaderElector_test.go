@@ -0,0 +1,56 @@
+package test_lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redislock "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisLeaderElector(t *testing.T) {
+	ctx := context.Background()
+
+	config := getLockConfig(
+		"options.role", "test_role",
+		"options.lease_timeout", 3000,
+	)
+
+	elector1 := redislock.NewRedisLeaderElector()
+	elector1.Configure(ctx, config)
+	err := elector1.Open(ctx, "")
+	assert.Nil(t, err)
+	defer elector1.Close(ctx, "")
+
+	// The first replica becomes the leader
+	select {
+	case leader := <-elector1.LeadershipChanges():
+		assert.True(t, leader)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "Leadership was not acquired")
+	}
+	assert.True(t, elector1.IsLeader())
+
+	elector2 := redislock.NewRedisLeaderElector()
+	elector2.Configure(ctx, config)
+	err = elector2.Open(ctx, "")
+	assert.Nil(t, err)
+	defer elector2.Close(ctx, "")
+
+	<-time.After(500 * time.Millisecond)
+	assert.False(t, elector2.IsLeader())
+
+	// The second replica takes over when the leader steps down
+	err = elector1.Close(ctx, "")
+	assert.Nil(t, err)
+	assert.False(t, elector1.IsLeader())
+
+	select {
+	case leader := <-elector2.LeadershipChanges():
+		assert.True(t, leader)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "Leadership was not taken over")
+	}
+	assert.True(t, elector2.IsLeader())
+}
@@ -4,18 +4,15 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
 	redislock "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
 	redisfixture "github.com/pip-services3-gox/pip-services3-redis-gox/test/fixture"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestRedisLock(t *testing.T) {
-	var lock *redislock.RedisLock
-	var fixture *redisfixture.LockFixture
-
-	ctx := context.Background()
-
+func getLockConfig(options ...any) *cconf.ConfigParams {
 	host := os.Getenv("REDIS_SERVICE_HOST")
 	if host == "" {
 		host = "localhost"
@@ -26,19 +23,294 @@ func TestRedisLock(t *testing.T) {
 		port = "6379"
 	}
 
-	lock = redislock.NewRedisLock()
-
 	config := cconf.NewConfigParamsFromTuples(
 		"connection.host", host,
 		"connection.port", port,
 	)
-	lock.Configure(ctx, config)
-	fixture = redisfixture.NewLockFixture(lock)
+	return config.Override(cconf.NewConfigParamsFromTuples(options...))
+}
+
+// newTestLock opens a lock configured with the given options, it is closed when the test ends.
+func newTestLock(t *testing.T, options ...any) *redislock.RedisLock {
+	ctx := context.Background()
+
+	lock := redislock.NewRedisLock()
+	lock.Configure(ctx, getLockConfig(options...))
+	err := lock.Open(ctx, "")
+	assert.Nil(t, err)
+	t.Cleanup(func() { lock.Close(ctx, "") })
+	return lock
+}
+
+func TestRedisLock(t *testing.T) {
+	lock := newTestLock(t)
 
-	lock.Open(ctx, "")
-	defer lock.Close(ctx, "")
+	fixture := redisfixture.NewLockFixture(lock)
 
 	t.Run("Try Acquire Lock", fixture.TestTryAcquireLock)
 	t.Run("Acquire Lock", fixture.TestAcquireLock)
 	t.Run("Release Lock", fixture.TestReleaseLock)
+	t.Run("Reentrant Lock", testRedisReentrantLock)
+	t.Run("Wait For Release", testRedisLockWaitForRelease)
+	t.Run("Shared Release Subscription", testRedisLockSharedReleaseSubscription)
+	t.Run("Fair Lock", func(t *testing.T) {
+		testRedisFairLock(t, "fair_lock_1", 3000)
+	})
+	t.Run("Fair Lock Short Queue Timeout", func(t *testing.T) {
+		// Too short queue timeout is raised, so the waiter keeps its place in the queue
+		testRedisFairLock(t, "fair_lock_2", 0)
+	})
+	t.Run("Lock Info", testRedisLockInfo)
+	t.Run("Extend And Force Release", testRedisLockExtendAndForceRelease)
+	t.Run("Cancel Acquisition", testRedisLockCancelAcquisition)
+	t.Run("Retry Options", testRedisLockRetryOptions)
+}
+
+func testRedisReentrantLock(t *testing.T) {
+	ctx := context.Background()
+
+	lock := newTestLock(t, "options.reentrant", true)
+	anotherLock := newTestLock(t, "options.reentrant", true)
+
+	// Acquire the lock twice by the same owner
+	result, err := lock.TryAcquireLock(ctx, "", "reentrant_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	result, err = lock.TryAcquireLock(ctx, "", "reentrant_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// Another owner cannot acquire the lock
+	result, err = anotherLock.TryAcquireLock(ctx, "", "reentrant_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	// The lock is still held after the first release
+	err = lock.ReleaseLock(ctx, "", "reentrant_lock_1")
+	assert.Nil(t, err)
+
+	result, err = anotherLock.TryAcquireLock(ctx, "", "reentrant_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	// The lock is freed after the second release
+	err = lock.ReleaseLock(ctx, "", "reentrant_lock_1")
+	assert.Nil(t, err)
+
+	result, err = anotherLock.TryAcquireLock(ctx, "", "reentrant_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	anotherLock.ReleaseLock(ctx, "", "reentrant_lock_1")
+}
+
+func testRedisLockWaitForRelease(t *testing.T) {
+	ctx := context.Background()
+
+	lock := newTestLock(t)
+	anotherLock := newTestLock(t)
+
+	result, err := lock.TryAcquireLock(ctx, "", "waiting_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// Release the lock while another owner waits for it
+	go func() {
+		<-time.After(300 * time.Millisecond)
+		lock.ReleaseLock(ctx, "", "waiting_lock_1")
+	}()
+
+	err = anotherLock.AcquireLock(ctx, "", "waiting_lock_1", 3000, 2000)
+	assert.Nil(t, err)
+
+	anotherLock.ReleaseLock(ctx, "", "waiting_lock_1")
+}
+
+func testRedisLockSharedReleaseSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	// Polling is too slow to get the locks in time, so waiters rely on notifications
+	lock := newTestLock(t)
+	waitingLock := newTestLock(t,
+		"options.retry_timeout", 5000,
+		"options.retry_max_timeout", 5000,
+		"options.retry_jitter", 0,
+	)
+
+	// Waits for different keys are woken through the subscription opened with the lock
+	for _, key := range []string{"shared_lock_1", "shared_lock_2"} {
+		result, err := lock.TryAcquireLock(ctx, "", key, 5000)
+		assert.Nil(t, err)
+		assert.True(t, result)
+
+		go func(key string) {
+			<-time.After(300 * time.Millisecond)
+			lock.ReleaseLock(ctx, "", key)
+		}(key)
+
+		start := time.Now()
+		err = waitingLock.AcquireLock(ctx, "", key, 5000, 3000)
+		assert.Nil(t, err)
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		waitingLock.ReleaseLock(ctx, "", key)
+	}
+}
+
+func testRedisFairLock(t *testing.T, key string, queueTimeout int64) {
+	ctx := context.Background()
+
+	options := []any{
+		"options.fair", true,
+		"options.queue_timeout", queueTimeout,
+	}
+
+	lock := newTestLock(t, options...)
+	waitingLock := newTestLock(t, options...)
+	lateLock := newTestLock(t, options...)
+
+	result, err := lock.TryAcquireLock(ctx, "", key, 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// The first waiter takes a place in the queue
+	done := make(chan error)
+	go func() {
+		done <- waitingLock.AcquireLock(ctx, "", key, 3000, 2000)
+	}()
+	<-time.After(300 * time.Millisecond)
+
+	err = lock.ReleaseLock(ctx, "", key)
+	assert.Nil(t, err)
+
+	// The late client cannot jump the queue
+	result, err = lateLock.TryAcquireLock(ctx, "", key, 3000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	err = <-done
+	assert.Nil(t, err)
+
+	waitingLock.ReleaseLock(ctx, "", key)
+}
+
+func testRedisLockInfo(t *testing.T) {
+	ctx := context.Background()
+
+	lock := newTestLock(t)
+
+	// Missing lock has no information
+	info, err := lock.GetLockInfo(ctx, "", "info_lock_1")
+	assert.Nil(t, err)
+	assert.Nil(t, info)
+
+	result, err := lock.TryAcquireLock(ctx, "", "info_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	info, err = lock.GetLockInfo(ctx, "", "info_lock_1")
+	assert.Nil(t, err)
+	assert.NotNil(t, info)
+	assert.NotEqual(t, "", info.Owner)
+	assert.Equal(t, os.Getpid(), info.Pid)
+	assert.Equal(t, 1, info.HoldCount)
+	assert.True(t, info.Ttl > 0 && info.Ttl <= 3000)
+	assert.False(t, info.AcquiredAt.IsZero())
+
+	locks, err := lock.ListLocks(ctx, "", "info_lock_*")
+	assert.Nil(t, err)
+	assert.Len(t, locks, 1)
+	assert.Equal(t, "info_lock_1", locks[0].Key)
+
+	err = lock.ReleaseLock(ctx, "", "info_lock_1")
+	assert.Nil(t, err)
+
+	locks, err = lock.ListLocks(ctx, "", "info_lock_*")
+	assert.Nil(t, err)
+	assert.Len(t, locks, 0)
+}
+
+func testRedisLockExtendAndForceRelease(t *testing.T) {
+	ctx := context.Background()
+
+	lock := newTestLock(t)
+	adminLock := newTestLock(t)
+
+	result, err := lock.TryAcquireLock(ctx, "", "extend_lock_1", 1000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// Only the owner can extend the lock
+	result, err = adminLock.ExtendLock(ctx, "", "extend_lock_1", 5000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	result, err = lock.ExtendLock(ctx, "", "extend_lock_1", 5000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	info, err := lock.GetLockInfo(ctx, "", "extend_lock_1")
+	assert.Nil(t, err)
+	assert.True(t, info.Ttl > 1000)
+
+	// Operator breaks the lock
+	err = adminLock.ForceReleaseLock(ctx, "", "extend_lock_1", "test")
+	assert.Nil(t, err)
+
+	info, err = lock.GetLockInfo(ctx, "", "extend_lock_1")
+	assert.Nil(t, err)
+	assert.Nil(t, info)
+
+	result, err = lock.ExtendLock(ctx, "", "extend_lock_1", 5000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+}
+
+func testRedisLockCancelAcquisition(t *testing.T) {
+	ctx := context.Background()
+
+	lock := newTestLock(t)
+	anotherLock := newTestLock(t)
+
+	result, err := lock.TryAcquireLock(ctx, "", "cancel_lock_1", 5000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// The acquisition stops when the context is cancelled
+	cancelCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = anotherLock.AcquireLock(cancelCtx, "", "cancel_lock_1", 5000, 5000)
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < 2*time.Second)
+
+	lock.ReleaseLock(ctx, "", "cancel_lock_1")
+}
+
+func testRedisLockRetryOptions(t *testing.T) {
+	ctx := context.Background()
+
+	options := []any{
+		"options.retry_timeout", 50,
+		"options.retry_max_timeout", 200,
+		"options.retry_multiplier", 1.5,
+		"options.retry_jitter", 0.2,
+	}
+
+	lock := newTestLock(t, options...)
+	anotherLock := newTestLock(t, options...)
+
+	result, err := lock.TryAcquireLock(ctx, "", "retry_lock_1", 500)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	// Expired locks send no notifications, so they are caught by polling
+	start := time.Now()
+	err = anotherLock.AcquireLock(ctx, "", "retry_lock_1", 3000, 2000)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 1000*time.Millisecond)
+
+	anotherLock.ReleaseLock(ctx, "", "retry_lock_1")
 }
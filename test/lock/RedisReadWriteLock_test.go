@@ -0,0 +1,115 @@
+package test_lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redislock "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisReadWriteLock(t *testing.T) {
+	ctx := context.Background()
+
+	config := getLockConfig()
+
+	reader := redislock.NewRedisReadWriteLock()
+	reader.Configure(ctx, config)
+	reader.Open(ctx, "")
+	defer reader.Close(ctx, "")
+
+	anotherReader := redislock.NewRedisReadWriteLock()
+	anotherReader.Configure(ctx, config)
+	anotherReader.Open(ctx, "")
+	defer anotherReader.Close(ctx, "")
+
+	writer := redislock.NewRedisReadWriteLock()
+	writer.Configure(ctx, config)
+	writer.Open(ctx, "")
+	defer writer.Close(ctx, "")
+
+	// Readers share the lock
+	token1, err := reader.TryAcquireReadLock(ctx, "", "rw_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token1)
+
+	token2, err := anotherReader.TryAcquireReadLock(ctx, "", "rw_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token2)
+
+	// Writer is blocked by readers
+	result, err := writer.TryAcquireWriteLock(ctx, "", "rw_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	// Waiting writer blocks new readers
+	err = writer.AcquireWriteLock(ctx, "", "rw_lock_1", 3000, 300)
+	assert.NotNil(t, err)
+
+	err = anotherReader.ReleaseReadLock(ctx, "", "rw_lock_1", token2)
+	assert.Nil(t, err)
+	err = reader.ReleaseReadLock(ctx, "", "rw_lock_1", token1)
+	assert.Nil(t, err)
+
+	// Writer gets an exclusive access
+	err = writer.AcquireWriteLock(ctx, "", "rw_lock_1", 3000, 1000)
+	assert.Nil(t, err)
+
+	token1, err = reader.TryAcquireReadLock(ctx, "", "rw_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.Equal(t, "", token1)
+
+	err = writer.ReleaseWriteLock(ctx, "", "rw_lock_1")
+	assert.Nil(t, err)
+
+	token1, err = reader.TryAcquireReadLock(ctx, "", "rw_lock_1", 3000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token1)
+
+	reader.ReleaseReadLock(ctx, "", "rw_lock_1", token1)
+}
+
+func TestRedisReadWriteLockSharedReaders(t *testing.T) {
+	ctx := context.Background()
+
+	config := getLockConfig()
+
+	lock := redislock.NewRedisReadWriteLock()
+	lock.Configure(ctx, config)
+	lock.Open(ctx, "")
+	defer lock.Close(ctx, "")
+
+	// Two readers acquire the lock through the same component
+	token1, err := lock.AcquireReadLock(ctx, "", "rw_lock_2", 3000, 1000)
+	assert.Nil(t, err)
+	token2, err := lock.AcquireReadLock(ctx, "", "rw_lock_2", 3000, 1000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, token1, token2)
+
+	// Releasing one reader keeps the lock held by another one
+	err = lock.ReleaseReadLock(ctx, "", "rw_lock_2", token1)
+	assert.Nil(t, err)
+
+	result, err := lock.TryAcquireWriteLock(ctx, "", "rw_lock_2", 3000)
+	assert.Nil(t, err)
+	assert.False(t, result)
+
+	// Waiting for the write lock stops on cancellation
+	cancelCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = lock.AcquireWriteLock(cancelCtx, "", "rw_lock_2", 3000, 5000)
+	assert.NotNil(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	err = lock.ReleaseReadLock(ctx, "", "rw_lock_2", token2)
+	assert.Nil(t, err)
+
+	result, err = lock.TryAcquireWriteLock(ctx, "", "rw_lock_2", 3000)
+	assert.Nil(t, err)
+	assert.True(t, result)
+
+	lock.ReleaseWriteLock(ctx, "", "rw_lock_2")
+}
@@ -0,0 +1,96 @@
+package test_lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	redislock "github.com/pip-services3-gox/pip-services3-redis-gox/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSemaphore(t *testing.T, permits int) *redislock.RedisSemaphore {
+	ctx := context.Background()
+
+	semaphore := redislock.NewRedisSemaphore()
+	semaphore.Configure(ctx, getLockConfig("options.permits", permits))
+	err := semaphore.Open(ctx, "")
+	assert.Nil(t, err)
+	t.Cleanup(func() { semaphore.Close(ctx, "") })
+	return semaphore
+}
+
+func TestRedisSemaphore(t *testing.T) {
+	ctx := context.Background()
+	semaphore := newTestSemaphore(t, 2)
+
+	// Take all permits
+	token1, err := semaphore.TryAcquire(ctx, "", "semaphore_1", 3000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token1)
+
+	token2, err := semaphore.TryAcquire(ctx, "", "semaphore_1", 3000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token2)
+
+	// No permits left
+	token3, err := semaphore.TryAcquire(ctx, "", "semaphore_1", 3000)
+	assert.Nil(t, err)
+	assert.Equal(t, "", token3)
+
+	_, err = semaphore.Acquire(ctx, "", "semaphore_1", 3000, 300)
+	assert.NotNil(t, err)
+
+	// Return one permit
+	err = semaphore.Release(ctx, "", "semaphore_1", token1)
+	assert.Nil(t, err)
+
+	token3, err = semaphore.Acquire(ctx, "", "semaphore_1", 3000, 1000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token3)
+
+	semaphore.Release(ctx, "", "semaphore_1", token2)
+	semaphore.Release(ctx, "", "semaphore_1", token3)
+}
+
+func TestRedisSemaphoreExpiredHolders(t *testing.T) {
+	ctx := context.Background()
+	semaphore := newTestSemaphore(t, 1)
+
+	token1, err := semaphore.TryAcquire(ctx, "", "semaphore_2", 200)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token1)
+
+	// The permit returns after the holder expires
+	token2, err := semaphore.Acquire(ctx, "", "semaphore_2", 3000, 1000)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token2)
+
+	semaphore.Release(ctx, "", "semaphore_2", token2)
+}
+
+func TestRedisSemaphoreCancel(t *testing.T) {
+	ctx := context.Background()
+	semaphore := newTestSemaphore(t, 1)
+
+	// A free permit is taken even without waiting
+	token1, err := semaphore.Acquire(ctx, "", "semaphore_3", 3000, 0)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", token1)
+	defer semaphore.Release(ctx, "", "semaphore_3", token1)
+
+	_, err = semaphore.Acquire(ctx, "", "semaphore_3", 3000, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, "SEMAPHORE_TIMEOUT", err.(*cerr.ApplicationError).Code)
+
+	// Waiting stops when the context is cancelled
+	cancelCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = semaphore.Acquire(cancelCtx, "", "semaphore_3", 3000, 5000)
+	assert.NotNil(t, err)
+	assert.Equal(t, "SEMAPHORE_CANCELLED", err.(*cerr.ApplicationError).Code)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
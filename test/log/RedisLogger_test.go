@@ -0,0 +1,112 @@
+package test_log
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	clog "github.com/pip-services3-gox/pip-services3-components-gox/log"
+	redisconnect "github.com/pip-services3-gox/pip-services3-redis-gox/connect"
+	redislog "github.com/pip-services3-gox/pip-services3-redis-gox/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func getConfig(key string, mode string) *cconf.ConfigParams {
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	return cconf.NewConfigParamsFromTuples(
+		"level", "trace",
+		"source", "test",
+		"connection.host", host,
+		"connection.port", port,
+		"options.key", key,
+		"options.mode", mode,
+		"options.max_length", 2,
+	)
+}
+
+func TestRedisLogger(t *testing.T) {
+	ctx := context.Background()
+
+	key := "test_logs_" + cdata.IdGenerator.NextShort()
+	config := getConfig(key, redislog.StreamMode)
+
+	connection := redisconnect.NewRedisConnection()
+	connection.Configure(ctx, config)
+	err := connection.Open(ctx, "")
+	assert.Nil(t, err)
+	defer connection.Close(ctx, "")
+	defer connection.GetClient().Del(key)
+
+	logger := redislog.NewRedisLogger()
+	logger.Configure(ctx, config)
+	err = logger.Open(ctx, "")
+	assert.Nil(t, err)
+
+	logger.Info(ctx, "123", "Test message")
+	logger.Error(ctx, "456", errors.New("Test error"), "Failed operation")
+
+	err = logger.Close(ctx, "")
+	assert.Nil(t, err)
+
+	messages, err := connection.GetClient().XRange(key, "-", "+").Result()
+	assert.Nil(t, err)
+	assert.Len(t, messages, 2)
+
+	assert.Equal(t, "123", messages[0].Values["correlation_id"])
+	assert.Equal(t, "INFO", messages[0].Values["level"])
+	assert.Equal(t, "Test message", messages[0].Values["message"])
+
+	assert.Equal(t, "456", messages[1].Values["correlation_id"])
+	assert.Contains(t, messages[1].Values["error"], "Test error")
+}
+
+func TestRedisListLogger(t *testing.T) {
+	ctx := context.Background()
+
+	key := "test_logs_" + cdata.IdGenerator.NextShort()
+	config := getConfig(key, redislog.ListMode)
+
+	connection := redisconnect.NewRedisConnection()
+	connection.Configure(ctx, config)
+	err := connection.Open(ctx, "")
+	assert.Nil(t, err)
+	defer connection.Close(ctx, "")
+	defer connection.GetClient().Del(key)
+
+	logger := redislog.NewRedisLogger()
+	logger.Configure(ctx, config)
+	err = logger.Open(ctx, "")
+	assert.Nil(t, err)
+
+	logger.Info(ctx, "123", "Message 1")
+	logger.Info(ctx, "123", "Message 2")
+	logger.Warn(ctx, "123", "Message 3")
+
+	err = logger.Close(ctx, "")
+	assert.Nil(t, err)
+
+	// The list is trimmed to the maximum length
+	values, err := connection.GetClient().LRange(key, 0, -1).Result()
+	assert.Nil(t, err)
+	assert.Len(t, values, 2)
+
+	var message clog.LogMessage
+	err = json.Unmarshal([]byte(values[1]), &message)
+	assert.Nil(t, err)
+	assert.Equal(t, "123", message.CorrelationId)
+	assert.Equal(t, "Message 3", message.Message)
+	assert.Equal(t, clog.LevelWarn, message.Level)
+}
@@ -0,0 +1,7 @@
+package test_persistence
+
+type Dummy struct {
+	Id      string `json:"id"`
+	Key     string `json:"key"`
+	Content string `json:"content"`
+}
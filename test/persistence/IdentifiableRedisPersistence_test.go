@@ -0,0 +1,41 @@
+package test_persistence
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	rpersist "github.com/pip-services3-gox/pip-services3-redis-gox/persistence"
+)
+
+func TestIdentifiableRedisPersistence(t *testing.T) {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	persistence := rpersist.NewIdentifiableRedisPersistence[Dummy, string]("dummies")
+	config := cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+	)
+	persistence.Configure(ctx, config)
+	persistence.EnsureIndex("key", rpersist.StringIndex)
+	fixture := NewPersistenceFixture(persistence)
+	persistence.Open(ctx, "")
+	defer persistence.Close(ctx, "")
+
+	persistence.Clear(ctx, "")
+
+	t.Run("TestIdentifiableRedisPersistence:CRUD Operations", fixture.TestCrudOperations)
+	t.Run("TestIdentifiableRedisPersistence:Page By Filter", fixture.TestPageByFilter)
+	t.Run("TestIdentifiableRedisPersistence:Page By Filter Order", fixture.TestPageByFilterOrder)
+}
@@ -0,0 +1,200 @@
+package test_persistence
+
+import (
+	"context"
+	"testing"
+
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	rpersist "github.com/pip-services3-gox/pip-services3-redis-gox/persistence"
+	"github.com/stretchr/testify/assert"
+)
+
+type PersistenceFixture struct {
+	dummy1      Dummy
+	dummy2      Dummy
+	persistence *rpersist.IdentifiableRedisPersistence[Dummy, string]
+}
+
+func NewPersistenceFixture(persistence *rpersist.IdentifiableRedisPersistence[Dummy, string]) *PersistenceFixture {
+	c := PersistenceFixture{}
+	c.dummy1 = Dummy{Id: "", Key: "Key 1", Content: "Content 1"}
+	c.dummy2 = Dummy{Id: "", Key: "Key 2", Content: "Content 2"}
+	c.persistence = persistence
+	return &c
+}
+
+func (c *PersistenceFixture) TestCrudOperations(t *testing.T) {
+	ctx := context.Background()
+
+	// Create one dummy
+	dummy1, err := c.persistence.Create(ctx, "", c.dummy1)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", dummy1.Id)
+	assert.Equal(t, c.dummy1.Key, dummy1.Key)
+	assert.Equal(t, c.dummy1.Content, dummy1.Content)
+
+	// Create the same dummy again
+	_, err = c.persistence.Create(ctx, "", dummy1)
+	assert.NotNil(t, err)
+
+	// Create another dummy
+	dummy2, err := c.persistence.Create(ctx, "", c.dummy2)
+	assert.Nil(t, err)
+	assert.Equal(t, c.dummy2.Key, dummy2.Key)
+
+	// Get all dummies
+	items, err := c.persistence.GetListByFilter(ctx, "", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	count, err := c.persistence.GetCountByFilter(ctx, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Update the dummy
+	dummy1.Content = "Updated Content 1"
+	result, err := c.persistence.Update(ctx, "", dummy1)
+	assert.Nil(t, err)
+	assert.Equal(t, dummy1.Id, result.Id)
+	assert.Equal(t, "Updated Content 1", result.Content)
+
+	// Partially update the dummy
+	result, err = c.persistence.UpdatePartially(ctx, "", dummy1.Id,
+		*cdata.NewAnyValueMapFromTuples("content", "Partially Updated Content 1"))
+	assert.Nil(t, err)
+	assert.Equal(t, dummy1.Key, result.Key)
+	assert.Equal(t, "Partially Updated Content 1", result.Content)
+
+	// Get the dummy by id
+	result, err = c.persistence.GetOneById(ctx, "", dummy1.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "Partially Updated Content 1", result.Content)
+
+	// Get dummies by ids
+	items, err = c.persistence.GetListByIds(ctx, "", []string{dummy1.Id, dummy2.Id, "unknown"})
+	assert.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	// Delete the dummy
+	result, err = c.persistence.DeleteById(ctx, "", dummy1.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, dummy1.Id, result.Id)
+
+	// Try to get the deleted dummy
+	result, err = c.persistence.GetOneById(ctx, "", dummy1.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Id)
+
+	// Update the deleted dummy
+	result, err = c.persistence.Update(ctx, "", dummy1)
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Id)
+
+	// Set the deleted dummy back
+	result, err = c.persistence.Set(ctx, "", dummy1)
+	assert.Nil(t, err)
+	assert.Equal(t, dummy1.Id, result.Id)
+
+	// Delete both dummies
+	err = c.persistence.DeleteByIds(ctx, "", []string{dummy1.Id, dummy2.Id})
+	assert.Nil(t, err)
+
+	count, err = c.persistence.GetCountByFilter(ctx, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func (c *PersistenceFixture) TestPageByFilter(t *testing.T) {
+	ctx := context.Background()
+
+	dummy1, err := c.persistence.Create(ctx, "", c.dummy1)
+	assert.Nil(t, err)
+	dummy2, err := c.persistence.Create(ctx, "", c.dummy2)
+	assert.Nil(t, err)
+
+	// Get a page sorted by the key in descending order
+	page, err := c.persistence.GetPageByFilter(ctx, "", nil,
+		cdata.NewPagingParams(0, 10, true),
+		cdata.NewSortParams([]cdata.SortField{cdata.NewSortField("key", false)}))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, page.Total)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, dummy2.Id, page.Data[0].Id)
+	assert.Equal(t, dummy1.Id, page.Data[1].Id)
+
+	// Skip the first item
+	page, err = c.persistence.GetPageByFilter(ctx, "", nil,
+		cdata.NewPagingParams(1, 10, false),
+		cdata.NewSortParams([]cdata.SortField{cdata.NewSortField("key", true)}))
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, dummy2.Id, page.Data[0].Id)
+
+	// Filter by the key
+	page, err = c.persistence.GetPageByFilter(ctx, "",
+		cdata.NewFilterParamsFromTuples("key", dummy1.Key), nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, dummy1.Id, page.Data[0].Id)
+
+	// Update the key and filter by the new value
+	dummy1.Key = "Key 3"
+	_, err = c.persistence.Update(ctx, "", dummy1)
+	assert.Nil(t, err)
+
+	page, err = c.persistence.GetPageByFilter(ctx, "",
+		cdata.NewFilterParamsFromTuples("key", c.dummy1.Key), nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 0)
+
+	page, err = c.persistence.GetPageByFilter(ctx, "",
+		cdata.NewFilterParamsFromTuples("key", "Key 3"), nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 1)
+
+	// Filter by not indexed field
+	_, err = c.persistence.GetPageByFilter(ctx, "",
+		cdata.NewFilterParamsFromTuples("content", dummy1.Content), nil, nil)
+	assert.NotNil(t, err)
+
+	err = c.persistence.DeleteByIds(ctx, "", []string{dummy1.Id, dummy2.Id})
+	assert.Nil(t, err)
+}
+
+func (c *PersistenceFixture) TestPageByFilterOrder(t *testing.T) {
+	ctx := context.Background()
+
+	dummy1, err := c.persistence.Create(ctx, "", Dummy{Id: "order_2", Key: "Key", Content: "Content 1"})
+	assert.Nil(t, err)
+	dummy2, err := c.persistence.Create(ctx, "", Dummy{Id: "order_1", Key: "Key", Content: "Content 2"})
+	assert.Nil(t, err)
+	dummy3, err := c.persistence.Create(ctx, "", Dummy{Id: "order_3", Key: "key", Content: "Content 3"})
+	assert.Nil(t, err)
+
+	// Values are compared case-sensitively
+	page, err := c.persistence.GetPageByFilter(ctx, "",
+		cdata.NewFilterParamsFromTuples("key", "key"), nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, dummy3.Id, page.Data[0].Id)
+
+	// Items with equal values are ordered by ids in every direction
+	page, err = c.persistence.GetPageByFilter(ctx, "", nil, nil,
+		cdata.NewSortParams([]cdata.SortField{cdata.NewSortField("key", true)}))
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 3)
+	assert.Equal(t, dummy2.Id, page.Data[0].Id)
+	assert.Equal(t, dummy1.Id, page.Data[1].Id)
+	assert.Equal(t, dummy3.Id, page.Data[2].Id)
+
+	page, err = c.persistence.GetPageByFilter(ctx, "", nil, nil,
+		cdata.NewSortParams([]cdata.SortField{cdata.NewSortField("key", false)}))
+	assert.Nil(t, err)
+	assert.Len(t, page.Data, 3)
+	assert.Equal(t, dummy3.Id, page.Data[0].Id)
+	assert.Equal(t, dummy2.Id, page.Data[1].Id)
+	assert.Equal(t, dummy1.Id, page.Data[2].Id)
+
+	err = c.persistence.DeleteByIds(ctx, "", []string{dummy1.Id, dummy2.Id, dummy3.Id})
+	assert.Nil(t, err)
+}
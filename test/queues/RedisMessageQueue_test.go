@@ -0,0 +1,189 @@
+package test_queues
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	redisqueues "github.com/pip-services3-gox/pip-services3-redis-gox/queues"
+	"github.com/stretchr/testify/assert"
+)
+
+type testReceiver struct {
+	messages chan *redisqueues.MessageEnvelope
+}
+
+func (c *testReceiver) ReceiveMessage(ctx context.Context, envelope *redisqueues.MessageEnvelope,
+	queue redisqueues.IMessageQueue) error {
+	c.messages <- envelope
+	return queue.Complete(ctx, envelope)
+}
+
+func newMessageQueue(t *testing.T, consumer string, options ...any) *redisqueues.RedisMessageQueue {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	queue := redisqueues.NewRedisMessageQueue("test_queue_" + cdata.IdGenerator.NextShort())
+	config := cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.consumer", consumer,
+		"options.max_deliveries", 2,
+		"options.visibility_timeout", 100,
+	)
+	queue.Configure(ctx, config.Override(cconf.NewConfigParamsFromTuples(options...)))
+	err := queue.Open(ctx, "")
+	assert.Nil(t, err)
+	return queue
+}
+
+func TestRedisMessageQueue(t *testing.T) {
+	ctx := context.Background()
+
+	queue := newMessageQueue(t, "consumer1")
+	defer queue.Close(ctx, "")
+	defer queue.Clear(ctx, "")
+
+	t.Run("Send, Receive and Complete", func(t *testing.T) {
+		err := queue.Send(ctx, "123", redisqueues.NewMessageEnvelope("", "Test", []byte("Test message")))
+		assert.Nil(t, err)
+
+		count, err := queue.ReadMessageCount(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), count)
+
+		peeked, err := queue.Peek(ctx, "")
+		assert.Nil(t, err)
+		assert.NotNil(t, peeked)
+		assert.Equal(t, "Test message", peeked.GetMessageAsString())
+
+		message, err := queue.Receive(ctx, "", 1000)
+		assert.Nil(t, err)
+		assert.NotNil(t, message)
+		assert.Equal(t, "123", message.CorrelationId)
+		assert.Equal(t, "Test", message.MessageType)
+		assert.Equal(t, "Test message", message.GetMessageAsString())
+
+		err = queue.Complete(ctx, message)
+		assert.Nil(t, err)
+
+		count, err = queue.ReadMessageCount(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Abandon and Dead Letter", func(t *testing.T) {
+		err := queue.Send(ctx, "", redisqueues.NewMessageEnvelope("", "Test", []byte("Poison message")))
+		assert.Nil(t, err)
+
+		// The message is delivered up to max deliveries
+		for i := 0; i < 2; i++ {
+			message, err := queue.Receive(ctx, "", 1000)
+			assert.Nil(t, err)
+			assert.NotNil(t, message)
+			assert.Equal(t, "Poison message", message.GetMessageAsString())
+
+			err = queue.Abandon(ctx, message)
+			assert.Nil(t, err)
+		}
+
+		message, err := queue.Receive(ctx, "", 0)
+		assert.Nil(t, err)
+		assert.Nil(t, message)
+
+		count, err := queue.ReadMessageCount(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Redeliver on Timeout", func(t *testing.T) {
+		err := queue.Send(ctx, "", redisqueues.NewMessageEnvelope("", "Test", []byte("Lost message")))
+		assert.Nil(t, err)
+
+		message, err := queue.Receive(ctx, "", 1000)
+		assert.Nil(t, err)
+		assert.NotNil(t, message)
+
+		time.Sleep(200 * time.Millisecond)
+
+		message, err = queue.Receive(ctx, "", 0)
+		assert.Nil(t, err)
+		assert.NotNil(t, message)
+		assert.Equal(t, "Lost message", message.GetMessageAsString())
+
+		err = queue.Complete(ctx, message)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Listen", func(t *testing.T) {
+		receiver := &testReceiver{messages: make(chan *redisqueues.MessageEnvelope, 1)}
+		queue.BeginListen(ctx, "", receiver)
+		defer queue.EndListen(ctx, "")
+
+		err := queue.Send(ctx, "", redisqueues.NewMessageEnvelope("", "Test", []byte("Listened message")))
+		assert.Nil(t, err)
+
+		select {
+		case message := <-receiver.messages:
+			assert.Equal(t, "Listened message", message.GetMessageAsString())
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Message was not received")
+		}
+	})
+}
+
+func TestRedisMessageQueueClaimStaleInBatches(t *testing.T) {
+	ctx := context.Background()
+
+	// Pending messages are checked in small batches
+	queue := newMessageQueue(t, "consumer1", "options.claim_batch_size", 2)
+	defer queue.Close(ctx, "")
+	defer queue.Clear(ctx, "")
+
+	for i := 0; i < 7; i++ {
+		err := queue.Send(ctx, "", redisqueues.NewMessageEnvelope("", "Test", []byte("Poison message")))
+		assert.Nil(t, err)
+	}
+
+	// Every message is delivered up to max deliveries and abandoned
+	for delivery := 0; delivery < 2; delivery++ {
+		messages := make([]*redisqueues.MessageEnvelope, 0)
+		for i := 0; i < 7; i++ {
+			message, err := queue.Receive(ctx, "", 1000)
+			assert.Nil(t, err)
+			assert.NotNil(t, message)
+			messages = append(messages, message)
+		}
+		for _, message := range messages {
+			err := queue.Abandon(ctx, message)
+			assert.Nil(t, err)
+		}
+	}
+
+	// Every receive checks at most two batches, when it wraps around the end of the pending list,
+	// so exhausted messages are moved to the dead letter queue by several receives
+	count := int64(7)
+	for i := 0; i < 5 && count > 0; i++ {
+		message, err := queue.Receive(ctx, "", 0)
+		assert.Nil(t, err)
+		assert.Nil(t, message)
+
+		newCount, err := queue.ReadMessageCount(ctx)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, count-newCount, int64(4))
+		count = newCount
+	}
+	assert.Equal(t, int64(0), count)
+}
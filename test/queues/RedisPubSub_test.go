@@ -0,0 +1,81 @@
+package test_queues
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	redisqueues "github.com/pip-services3-gox/pip-services3-redis-gox/queues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPubSub(t *testing.T) {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	pubsub := redisqueues.NewRedisPubSub()
+	pubsub.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+	))
+
+	messages := make(chan *redisqueues.MessageEnvelope, 10)
+	err := pubsub.Subscribe(ctx, "", "test_pubsub.*", func(ctx context.Context, channel string,
+		envelope *redisqueues.MessageEnvelope) error {
+		assert.Equal(t, "test_pubsub.created", channel)
+		messages <- envelope
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = pubsub.Open(ctx, "")
+	assert.Nil(t, err)
+	defer pubsub.Close(ctx, "")
+
+	// Wait for the subscription to be established
+	time.Sleep(100 * time.Millisecond)
+
+	err = pubsub.Publish(ctx, "123", "test_pubsub.created", redisqueues.NewMessageEnvelope("", "Test", []byte("Test message")))
+	assert.Nil(t, err)
+
+	select {
+	case message := <-messages:
+		assert.Equal(t, "123", message.CorrelationId)
+		assert.Equal(t, "Test", message.MessageType)
+		assert.Equal(t, "Test message", message.GetMessageAsString())
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Message was not received")
+	}
+
+	// Subscriptions are restored after reopening
+	err = pubsub.Close(ctx, "")
+	assert.Nil(t, err)
+	err = pubsub.Open(ctx, "")
+	assert.Nil(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = pubsub.Publish(ctx, "", "test_pubsub.created", redisqueues.NewMessageEnvelope("", "Test", []byte("Another message")))
+	assert.Nil(t, err)
+
+	select {
+	case message := <-messages:
+		assert.Equal(t, "Another message", message.GetMessageAsString())
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Message was not received after reopening")
+	}
+
+	err = pubsub.Unsubscribe(ctx, "", "test_pubsub.*")
+	assert.Nil(t, err)
+}
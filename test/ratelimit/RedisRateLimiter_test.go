@@ -0,0 +1,118 @@
+package test_ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cdata "github.com/pip-services3-gox/pip-services3-commons-gox/data"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	redisratelimit "github.com/pip-services3-gox/pip-services3-redis-gox/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimiter(t *testing.T, algorithm string) *redisratelimit.RedisRateLimiter {
+	ctx := context.Background()
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	limiter := redisratelimit.NewRedisRateLimiter()
+	limiter.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+		"options.algorithm", algorithm,
+		"options.limit", 3,
+		"options.interval", 10000,
+	))
+	err := limiter.Open(ctx, "")
+	assert.Nil(t, err)
+	return limiter
+}
+
+func testAllow(t *testing.T, algorithm string) {
+	ctx := context.Background()
+
+	limiter := newRateLimiter(t, algorithm)
+	defer limiter.Close(ctx, "")
+
+	key := "rate_limit_" + algorithm + "_" + cdata.IdGenerator.NextLong()
+
+	result, err := limiter.Allow(ctx, "", key, 2)
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, int64(1), result.Remaining)
+
+	result, err = limiter.Allow(ctx, "", key, 1)
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, int64(0), result.Remaining)
+
+	// The quota is exhausted
+	result, err = limiter.Allow(ctx, "", key, 1)
+	assert.Nil(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, int64(0), result.Remaining)
+	assert.True(t, result.RetryAfter > 0)
+}
+
+func TestRedisRateLimiterFixedWindow(t *testing.T) {
+	testAllow(t, redisratelimit.FixedWindowAlgorithm)
+}
+
+func TestRedisRateLimiterSlidingWindow(t *testing.T) {
+	testAllow(t, redisratelimit.SlidingWindowAlgorithm)
+}
+
+func TestRedisRateLimiterTokenBucket(t *testing.T) {
+	testAllow(t, redisratelimit.TokenBucketAlgorithm)
+}
+
+func TestRedisRateLimiterWrongAlgorithm(t *testing.T) {
+	ctx := context.Background()
+
+	limiter := redisratelimit.NewRedisRateLimiter()
+	limiter.Configure(ctx, cconf.NewConfigParamsFromTuples(
+		"connection.host", "localhost",
+		"options.algorithm", "unknown",
+	))
+	err := limiter.Open(ctx, "")
+	assert.NotNil(t, err)
+}
+
+func TestRedisRateLimiterWrongRequestCount(t *testing.T) {
+	ctx := context.Background()
+
+	algorithms := []string{
+		redisratelimit.FixedWindowAlgorithm,
+		redisratelimit.SlidingWindowAlgorithm,
+		redisratelimit.TokenBucketAlgorithm,
+	}
+	for _, algorithm := range algorithms {
+		limiter := newRateLimiter(t, algorithm)
+		defer limiter.Close(ctx, "")
+
+		key := "rate_limit_count_" + algorithm + "_" + cdata.IdGenerator.NextLong()
+
+		// Negative, zero and above the limit numbers are rejected
+		for _, n := range []int64{-5, 0, 4} {
+			_, err := limiter.Allow(ctx, "", key, n)
+			assert.NotNil(t, err)
+			assert.Equal(t, "WRONG_REQUEST_COUNT", err.(*cerr.ApplicationError).Code)
+		}
+
+		// The quota is not changed by rejected requests
+		result, err := limiter.Allow(ctx, "", key, 3)
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(0), result.Remaining)
+	}
+}
@@ -0,0 +1,113 @@
+package test_scripts
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-redis/redis"
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	redisscripts "github.com/pip-services3-gox/pip-services3-redis-gox/scripts"
+	"github.com/stretchr/testify/assert"
+)
+
+const incrMaxScript = `
+local value = redis.call('INCR', KEYS[1])
+if value > tonumber(ARGV[1]) then
+	redis.call('SET', KEYS[1], ARGV[1])
+	return tonumber(ARGV[1])
+end
+return value
+`
+
+func getConfig() *cconf.ConfigParams {
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	return cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+	)
+}
+
+func TestRedisScripts(t *testing.T) {
+	ctx := context.Background()
+
+	scripts := redisscripts.NewRedisScripts()
+	scripts.Configure(ctx, getConfig())
+	scripts.Register("incr_max", incrMaxScript)
+	scripts.Register("echo", "return ARGV")
+	err := scripts.Open(ctx, "")
+	assert.Nil(t, err)
+	defer scripts.Close(ctx, "")
+
+	t.Run("TestRedisScripts:Run", func(t *testing.T) {
+		scripts.Run(ctx, "", "incr_max", []string{"test:scripts:counter"}, 0)
+
+		value, err := scripts.Run(ctx, "", "incr_max", []string{"test:scripts:counter"}, 1).Int64()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), value)
+
+		values, err := scripts.Run(ctx, "", "echo", nil, "A", "B").Strings()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"A", "B"}, values)
+
+		err = scripts.Run(ctx, "", "unknown", nil).Err()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("TestRedisScripts:Reload", func(t *testing.T) {
+		cache := rediscache.NewRedisCache[string]()
+		cache.Configure(ctx, getConfig())
+		cache.GetScripts().Register("echo", "return ARGV[1]")
+		err := cache.Open(ctx, "")
+		assert.Nil(t, err)
+		defer cache.Close(ctx, "")
+
+		config := getConfig()
+		client := redis.NewClient(&redis.Options{
+			Addr: config.GetAsString("connection.host") + ":" + config.GetAsString("connection.port"),
+		})
+		defer client.Close()
+
+		// Flushed scripts are loaded again on NOSCRIPT errors
+		_, err = redisscripts.NewClientScriptExecutor(client).Do("SCRIPT", "FLUSH")
+		assert.Nil(t, err)
+
+		value, err := cache.GetScripts().Run(ctx, "", "echo", nil, "B").String()
+		assert.Nil(t, err)
+		assert.Equal(t, "B", value)
+	})
+
+	t.Run("TestRedisScripts:Run after flush", func(t *testing.T) {
+		config := getConfig()
+		client := redis.NewClient(&redis.Options{
+			Addr: config.GetAsString("connection.host") + ":" + config.GetAsString("connection.port"),
+		})
+		defer client.Close()
+
+		executor := redisscripts.NewClientScriptExecutor(client)
+		_, err := executor.Do("SCRIPT", "FLUSH")
+		assert.Nil(t, err)
+
+		scripts.Run(ctx, "", "incr_max", []string{"test:scripts:flushed"}, 0)
+		value, err := scripts.Run(ctx, "", "incr_max", []string{"test:scripts:flushed"}, 5).Int64()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), value)
+
+		// The script is cached again by the node that executed it
+		exists, err := client.ScriptExists(scripts.GetSha("incr_max")).Result()
+		assert.Nil(t, err)
+		assert.Equal(t, []bool{true}, exists)
+
+		client.Del("test:scripts:flushed")
+	})
+}
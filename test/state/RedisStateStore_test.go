@@ -0,0 +1,77 @@
+package test_state
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cconf "github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerr "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	cstate "github.com/pip-services3-gox/pip-services3-components-gox/state"
+	rediscache "github.com/pip-services3-gox/pip-services3-redis-gox/cache"
+	redisstate "github.com/pip-services3-gox/pip-services3-redis-gox/state"
+	redisfixture "github.com/pip-services3-gox/pip-services3-redis-gox/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStateStore(t *testing.T) {
+	ctx := context.Background()
+
+	var store *redisstate.RedisStateStore[string]
+	var fixture *redisfixture.StateStoreFixture
+
+	host := os.Getenv("REDIS_SERVICE_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	port := os.Getenv("REDIS_SERVICE_PORT")
+	if port == "" {
+		port = "6379"
+	}
+
+	store = redisstate.NewRedisStateStore[string]()
+	config := cconf.NewConfigParamsFromTuples(
+		"connection.host", host,
+		"connection.port", port,
+	)
+	store.Configure(ctx, config)
+	fixture = redisfixture.NewStateStoreFixture(store)
+	store.Open(ctx, "")
+	defer store.Close(ctx, "")
+
+	t.Run("TestRedisStateStore:Save and Load", fixture.TestSaveAndLoad)
+	t.Run("TestRedisStateStore:Delete", fixture.TestDelete)
+	t.Run("TestRedisStateStore:Save Bulk", func(t *testing.T) {
+		values := store.SaveBulk(ctx, "", []cstate.StateValue[string]{
+			{Key: "bulk_state_key1", Value: "A"},
+			{Key: "bulk_state_key2", Value: "B"},
+		})
+		assert.Len(t, values, 2)
+
+		values = store.LoadBulk(ctx, "", []string{"bulk_state_key1", "bulk_state_key2"})
+		assert.Len(t, values, 2)
+
+		store.Delete(ctx, "", "bulk_state_key1")
+		store.Delete(ctx, "", "bulk_state_key2")
+	})
+}
+
+func TestRedisStateStoreConnection(t *testing.T) {
+	ctx := context.Background()
+
+	// The store resolves connections the same way as the cache
+	store := redisstate.NewRedisStateStore[string]()
+	store.Configure(ctx, cconf.NewEmptyConfigParams())
+	storeErr := store.Open(ctx, "")
+
+	cache := rediscache.NewRedisCache[string]()
+	cache.Configure(ctx, cconf.NewEmptyConfigParams())
+	cacheErr := cache.Open(ctx, "")
+
+	assert.NotNil(t, storeErr)
+	assert.NotNil(t, cacheErr)
+	assert.Equal(t, "NO_CONNECTION", storeErr.(*cerr.ApplicationError).Code)
+	assert.Equal(t, cacheErr.(*cerr.ApplicationError).Code, storeErr.(*cerr.ApplicationError).Code)
+	assert.False(t, store.IsOpen())
+}